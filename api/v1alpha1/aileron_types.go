@@ -0,0 +1,94 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// AileronSpec defines the desired state of Aileron. The resource describes
+// the pair of ailerons, one on each wing.
+type AileronSpec struct {
+	// Left is the desired deflection of the left aileron in degrees.
+	// Positive values move the trailing edge down, negative values move
+	// it up.
+	Left int32 `json:"left,omitempty"`
+
+	// Right is the desired deflection of the right aileron in degrees.
+	// Positive values move the trailing edge down, negative values move
+	// it up.
+	Right int32 `json:"right,omitempty"`
+
+	// MinDeflection is the travel limit with the trailing edge up, in
+	// degrees.
+	// +kubebuilder:validation:Maximum:=0
+	// +kubebuilder:default:=-20
+	MinDeflection int32 `json:"minDeflection,omitempty"`
+
+	// MaxDeflection is the travel limit with the trailing edge down, in
+	// degrees.
+	// +kubebuilder:validation:Minimum:=0
+	// +kubebuilder:default:=15
+	MaxDeflection int32 `json:"maxDeflection,omitempty"`
+
+	// SlewRate is the speed of the aileron actuators, in degrees per second.
+	// +kubebuilder:validation:Minimum:=1
+	// +kubebuilder:validation:Maximum:=1000
+	// +kubebuilder:default:=60
+	SlewRate int32 `json:"slewRate,omitempty"`
+}
+
+// AileronStatus defines the observed state of Aileron
+type AileronStatus struct {
+	// Left is the current deflection of the left aileron in degrees.
+	Left int32 `json:"left,omitempty"`
+
+	// Right is the current deflection of the right aileron in degrees.
+	Right int32 `json:"right,omitempty"`
+
+	// LastMoved is the time the actuators last advanced the ailerons. It
+	// is cleared when both ailerons reach the desired deflection.
+	LastMoved *metav1.MicroTime `json:"lastMoved,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="LEFT",type="integer",JSONPath=".status.left",description="Current deflection of left aileron in degrees"
+//+kubebuilder:printcolumn:name="RIGHT",type="integer",JSONPath=".status.right",description="Current deflection of right aileron in degrees"
+//+kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+
+// Aileron is the Schema for the ailerons API
+type Aileron struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   AileronSpec   `json:"spec"`
+	Status AileronStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// AileronList contains a list of Aileron
+type AileronList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Aileron `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Aileron{}, &AileronList{})
+}
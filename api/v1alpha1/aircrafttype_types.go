@@ -0,0 +1,208 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// SurfaceParameters describes the travel limits and actuator of a control
+// surface. Values that are left out take the surface's own defaults.
+type SurfaceParameters struct {
+	// MinDeflection is the travel limit on the negative side, in degrees.
+	// A rudder given zero can't move to that side.
+	// +kubebuilder:validation:Maximum:=0
+	// +optional
+	MinDeflection *int32 `json:"minDeflection,omitempty"`
+
+	// MaxDeflection is the travel limit on the positive side, in degrees.
+	// A rudder given zero can't move to that side.
+	// +kubebuilder:validation:Minimum:=0
+	// +optional
+	MaxDeflection *int32 `json:"maxDeflection,omitempty"`
+
+	// SlewRate is the speed of the actuator, in degrees per second.
+	// +kubebuilder:validation:Minimum:=0
+	// +kubebuilder:validation:Maximum:=1000
+	SlewRate int32 `json:"slewRate,omitempty"`
+}
+
+// ElevatorParameters describes the elevator and its trim.
+type ElevatorParameters struct {
+	SurfaceParameters `json:",inline"`
+
+	// MaxTrim is how far the trim tab can bias the elevator in either
+	// direction, in degrees.
+	// +kubebuilder:validation:Minimum:=0
+	MaxTrim int32 `json:"maxTrim,omitempty"`
+}
+
+// FlapsParameters describes the flaps.
+type FlapsParameters struct {
+	// MaxPosition is the travel limit of the flaps, in degrees.
+	// +kubebuilder:validation:Minimum:=0
+	MaxPosition int32 `json:"maxPosition,omitempty"`
+
+	// ExtensionRate is the speed of the flap motor, in degrees per second.
+	// +kubebuilder:validation:Minimum:=0
+	// +kubebuilder:validation:Maximum:=1000
+	ExtensionRate int32 `json:"extensionRate,omitempty"`
+
+	// MaxExtensionSpeed is the maximum flap extended speed, in knots of
+	// indicated airspeed.
+	// +kubebuilder:validation:Minimum:=0
+	MaxExtensionSpeed int32 `json:"maxExtensionSpeed,omitempty"`
+}
+
+// LandingGearParameters describes retractable landing gear.
+type LandingGearParameters struct {
+	// ExtensionTime is how long the gear takes to go from up-locked to
+	// down-locked.
+	ExtensionTime *metav1.Duration `json:"extensionTime,omitempty"`
+
+	// RetractionTime is how long the gear takes to go from down-locked to
+	// up-locked.
+	RetractionTime *metav1.Duration `json:"retractionTime,omitempty"`
+}
+
+// AerodynamicsParameters are the coefficients of the flight dynamics model.
+// Values that are left out, or zero, take the model's defaults, which
+// describe a light single-engine airplane.
+type AerodynamicsParameters struct {
+	// Mass is the gross weight in kilograms.
+	// +kubebuilder:validation:Minimum:=0
+	Mass float64 `json:"mass,omitempty"`
+
+	// WingArea is the reference wing area in square meters.
+	// +kubebuilder:validation:Minimum:=0
+	WingArea float64 `json:"wingArea,omitempty"`
+
+	// MaxThrust is the static thrust at full throttle in newtons.
+	// +kubebuilder:validation:Minimum:=0
+	MaxThrust float64 `json:"maxThrust,omitempty"`
+
+	// PropellerSpeed is the airspeed, in meters per second, at which the
+	// engine no longer produces thrust.
+	// +kubebuilder:validation:Minimum:=0
+	PropellerSpeed float64 `json:"propellerSpeed,omitempty"`
+
+	// LiftCoefficient is the lift coefficient at zero angle of attack.
+	// +kubebuilder:validation:Minimum:=-1
+	// +kubebuilder:validation:Maximum:=2
+	LiftCoefficient float64 `json:"liftCoefficient,omitempty"`
+
+	// LiftSlope is the change in lift coefficient per radian of angle of
+	// attack.
+	// +kubebuilder:validation:Minimum:=0
+	// +kubebuilder:validation:Maximum:=10
+	LiftSlope float64 `json:"liftSlope,omitempty"`
+
+	// StallAngle is the critical angle of attack in degrees.
+	// +kubebuilder:validation:Minimum:=0
+	// +kubebuilder:validation:Maximum:=45
+	StallAngle float64 `json:"stallAngle,omitempty"`
+
+	// ParasiteDrag is the zero-lift drag coefficient.
+	// +kubebuilder:validation:Minimum:=0
+	// +kubebuilder:validation:Maximum:=1
+	ParasiteDrag float64 `json:"parasiteDrag,omitempty"`
+
+	// InducedDrag is the drag coefficient added per squared lift
+	// coefficient.
+	// +kubebuilder:validation:Minimum:=0
+	// +kubebuilder:validation:Maximum:=1
+	InducedDrag float64 `json:"inducedDrag,omitempty"`
+
+	// GearDrag is the drag coefficient added by extended landing gear.
+	// +kubebuilder:validation:Minimum:=0
+	// +kubebuilder:validation:Maximum:=1
+	GearDrag float64 `json:"gearDrag,omitempty"`
+
+	// FlapLift is the lift coefficient added by full flaps.
+	// +kubebuilder:validation:Minimum:=0
+	// +kubebuilder:validation:Maximum:=2
+	FlapLift float64 `json:"flapLift,omitempty"`
+
+	// FlapDrag is the drag coefficient added by full flaps.
+	// +kubebuilder:validation:Minimum:=0
+	// +kubebuilder:validation:Maximum:=1
+	FlapDrag float64 `json:"flapDrag,omitempty"`
+
+	// RollPower is the roll rate, in degrees per second, per degree of
+	// aileron deflection.
+	// +kubebuilder:validation:Minimum:=0
+	// +kubebuilder:validation:Maximum:=20
+	RollPower float64 `json:"rollPower,omitempty"`
+}
+
+// AircraftTypeSpec defines the desired state of AircraftType
+type AircraftTypeSpec struct {
+	// Description is the make and model, for people to read.
+	Description string `json:"description,omitempty"`
+
+	// Rudder describes the rudder.
+	Rudder SurfaceParameters `json:"rudder,omitempty"`
+
+	// Aileron describes the ailerons.
+	Aileron SurfaceParameters `json:"aileron,omitempty"`
+
+	// Elevator describes the elevator.
+	Elevator ElevatorParameters `json:"elevator,omitempty"`
+
+	// Flaps describes the flaps. Types without flaps leave it out, and
+	// their airplanes get neither a flap lever nor flaps.
+	// +optional
+	Flaps *FlapsParameters `json:"flaps,omitempty"`
+
+	// LandingGear describes retractable landing gear. Types with fixed
+	// gear leave it out, and their airplanes get neither a gear lever nor
+	// landing gear.
+	// +optional
+	LandingGear *LandingGearParameters `json:"landingGear,omitempty"`
+
+	// Aerodynamics are the coefficients of the flight dynamics model.
+	Aerodynamics AerodynamicsParameters `json:"aerodynamics,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:resource:scope=Cluster
+//+kubebuilder:printcolumn:name="DESCRIPTION",type="string",JSONPath=".spec.description",description="Make and model"
+//+kubebuilder:printcolumn:name="MAXFLAPS",type="integer",JSONPath=".spec.flaps.maxPosition",description="Flap travel in degrees"
+//+kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+
+// AircraftType is the Schema for the aircrafttypes API. It is a catalog
+// entry that describes which parts an airplane of this type has and how
+// they behave.
+type AircraftType struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec AircraftTypeSpec `json:"spec"`
+}
+
+//+kubebuilder:object:root=true
+
+// AircraftTypeList contains a list of AircraftType
+type AircraftTypeList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []AircraftType `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&AircraftType{}, &AircraftTypeList{})
+}
@@ -0,0 +1,121 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	"github.com/roehrich-hpe/airplane-sim/api/v1beta1"
+)
+
+// ConvertTo converts this Airplane to the Hub version (v1beta1).
+func (src *Airplane) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*v1beta1.Airplane)
+
+	dst.ObjectMeta = src.ObjectMeta
+	dst.Spec = v1beta1.AirplaneSpec(src.Spec)
+
+	dst.Status.TailNumber = src.Status.TailNumber
+	for _, registration := range src.Status.PreviousRegistrations {
+		dst.Status.PreviousRegistrations = append(dst.Status.PreviousRegistrations, v1beta1.PreviousRegistration(registration))
+	}
+	dst.Status.ICAOAddress = src.Status.ICAOAddress
+	dst.Status.ObservedGeneration = src.Status.ObservedGeneration
+	dst.Status.Conditions = src.Status.Conditions
+	dst.Status.Airspeed = src.Status.Airspeed
+	dst.Status.WeightOnWheels = src.Status.WeightOnWheels
+	if src.Status.Flight != nil {
+		flight := v1beta1.FlightStatus(*src.Status.Flight)
+		dst.Status.Flight = &flight
+	}
+	dst.Status.Gear = v1beta1.GearSummary(src.Status.Gear)
+	dst.Status.Rudder = src.Status.Rudder
+	dst.Status.Pedals = src.Status.Pedals
+	dst.Status.Yoke = src.Status.Yoke
+	dst.Status.Aileron = src.Status.Aileron
+	dst.Status.Elevator = src.Status.Elevator
+	dst.Status.TrimWheel = src.Status.TrimWheel
+	dst.Status.FlapLever = src.Status.FlapLever
+	dst.Status.Flaps = src.Status.Flaps
+	dst.Status.GearLever = src.Status.GearLever
+	dst.Status.LandingGear = src.Status.LandingGear
+	return nil
+}
+
+// ConvertFrom converts from the Hub version (v1beta1) to this version.
+func (dst *Airplane) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*v1beta1.Airplane)
+
+	dst.ObjectMeta = src.ObjectMeta
+	dst.Spec = AirplaneSpec(src.Spec)
+
+	dst.Status.TailNumber = src.Status.TailNumber
+	for _, registration := range src.Status.PreviousRegistrations {
+		dst.Status.PreviousRegistrations = append(dst.Status.PreviousRegistrations, PreviousRegistration(registration))
+	}
+	dst.Status.ICAOAddress = src.Status.ICAOAddress
+	dst.Status.ObservedGeneration = src.Status.ObservedGeneration
+	dst.Status.Conditions = src.Status.Conditions
+	dst.Status.Airspeed = src.Status.Airspeed
+	dst.Status.WeightOnWheels = src.Status.WeightOnWheels
+	if src.Status.Flight != nil {
+		flight := FlightStatus(*src.Status.Flight)
+		dst.Status.Flight = &flight
+	}
+	dst.Status.Gear = GearSummary(src.Status.Gear)
+	dst.Status.Rudder = src.Status.Rudder
+	dst.Status.Pedals = src.Status.Pedals
+	dst.Status.Yoke = src.Status.Yoke
+	dst.Status.Aileron = src.Status.Aileron
+	dst.Status.Elevator = src.Status.Elevator
+	dst.Status.TrimWheel = src.Status.TrimWheel
+	dst.Status.FlapLever = src.Status.FlapLever
+	dst.Status.Flaps = src.Status.Flaps
+	dst.Status.GearLever = src.Status.GearLever
+	dst.Status.LandingGear = src.Status.LandingGear
+	return nil
+}
+
+// setAnnotation sets an annotation, creating the annotations if need be.
+func setAnnotation(meta *metav1.ObjectMeta, key, value string) {
+	annotations := map[string]string{}
+	for k, v := range meta.Annotations {
+		annotations[k] = v
+	}
+	annotations[key] = value
+	meta.Annotations = annotations
+}
+
+// popAnnotation removes an annotation and returns its value.
+func popAnnotation(meta *metav1.ObjectMeta, key string) (string, bool) {
+	value, ok := meta.Annotations[key]
+	if !ok {
+		return "", false
+	}
+	annotations := map[string]string{}
+	for k, v := range meta.Annotations {
+		if k != key {
+			annotations[k] = v
+		}
+	}
+	if len(annotations) == 0 {
+		annotations = nil
+	}
+	meta.Annotations = annotations
+	return value, true
+}
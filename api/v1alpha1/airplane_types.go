@@ -21,25 +21,192 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+// ICAOAddressLabel carries the airplane's Mode S address, so airplanes can be
+// selected by transponder code.
+const ICAOAddressLabel = "play.github.com/icao-address"
+
 // AirplaneSpec defines the desired state of Airplane
 type AirplaneSpec struct {
-	// TailNumber is "N-number" registration on our tail. We support only: Nxxxxx, where X is a digit or an uppercase letter.
-	// +kubebuilder:validation:Pattern:="^N[A-Z\\d]{5}$"
+	// TailNumber is the registration mark painted on our tail.  A United
+	// States "N-number" is N and then one to five characters: a digit
+	// other than zero, more digits, and at most two letters at the end,
+	// never I or O.  Other countries' marks are a prefix, a hyphen and
+	// letters, such as G-ABCD; the admission webhook checks them against
+	// the rules of the Country's register.
+	// +kubebuilder:validation:Pattern:="^(N[1-9]([0-9]{0,4}|[0-9]{0,3}[A-HJ-NP-Z]|[0-9]{0,2}[A-HJ-NP-Z]{2})|[A-Z]{1,2}-[A-Z0-9]{3,5})$"
+	TailNumber string `json:"tailNumber"`
+
+	// Country is the ISO 3166 code of the country whose register the
+	// tail number is in.
+	// +kubebuilder:validation:Enum:=US;GB;DE;CA;AU
+	// +kubebuilder:default:=US
+	// +optional
+	Country string `json:"country,omitempty"`
+
+	// TypeRef names the AircraftType that describes the airplane's parts.
+	// Without it the airplane gets the full set of parts with their
+	// default parameters.
+	// +optional
+	TypeRef *corev1.LocalObjectReference `json:"typeRef,omitempty"`
+
+	// Simulated enables the flight dynamics model, which flies the
+	// airplane and publishes its airspeed and squat switch. When it is
+	// false those are left for someone else to set.
+	Simulated bool `json:"simulated,omitempty"`
+
+	// Throttle is the engine power as a percentage of full power.
+	// +kubebuilder:validation:Minimum:=0
+	// +kubebuilder:validation:Maximum:=100
+	Throttle int32 `json:"throttle,omitempty"`
+}
+
+// FlightStatus is the position and motion of the airplane, as computed by
+// the flight dynamics model.
+type FlightStatus struct {
+	// North is the distance north of the starting point in meters
+	North float64 `json:"north"`
+
+	// East is the distance east of the starting point in meters
+	East float64 `json:"east"`
+
+	// Altitude is the height above the runway in feet
+	Altitude float64 `json:"altitude"`
+
+	// Heading is the direction of flight in degrees
+	Heading float64 `json:"heading"`
+
+	// Pitch is the attitude of the nose above the horizon in degrees
+	Pitch float64 `json:"pitch"`
+
+	// Roll is the bank angle in degrees, positive with the right wing down
+	Roll float64 `json:"roll"`
+
+	// AngleOfAttack is the angle between the wing and the flight path in
+	// degrees
+	AngleOfAttack float64 `json:"angleOfAttack"`
+
+	// FlightPath is the climb angle of the flight path in degrees
+	FlightPath float64 `json:"flightPath"`
+
+	// TrueAirspeed is the true airspeed in knots
+	TrueAirspeed float64 `json:"trueAirspeed"`
+
+	// VerticalSpeed is the rate of climb in feet per minute
+	VerticalSpeed float64 `json:"verticalSpeed"`
+
+	// LastStepped is the time the model was last advanced
+	LastStepped *metav1.MicroTime `json:"lastStepped,omitempty"`
+}
+
+// GearSummary summarizes the state of the landing gear
+type GearSummary struct {
+	// Lever is where the gear lever is placed
+	Lever string `json:"lever,omitempty"`
+
+	// State is the state of the gear
+	State string `json:"state,omitempty"`
+
+	// RetractionBlocked indicates that the squat switch is keeping the
+	// gear down
+	RetractionBlocked bool `json:"retractionBlocked,omitempty"`
+}
+
+// PreviousRegistration is a tail number the airplane was registered under
+// before it was re-registered.
+type PreviousRegistration struct {
+	// TailNumber is the mark the airplane was registered under
 	TailNumber string `json:"tailNumber"`
+
+	// Until is when the airplane was re-registered under a new tail number
+	Until metav1.Time `json:"until"`
 }
 
 // AirplaneStatus defines the observed state of Airplane
 type AirplaneStatus struct {
+	// TailNumber is the tail number the airplane's parts are named after.
+	// When the spec's tail number changes the parts are moved to the new
+	// name and this follows.
+	TailNumber string `json:"tailNumber,omitempty"`
+
+	// PreviousRegistrations are the tail numbers the airplane was
+	// registered under before, oldest first.
+	PreviousRegistrations []PreviousRegistration `json:"previousRegistrations,omitempty"`
+
+	// ICAOAddress is the 24-bit Mode S address of the airplane's
+	// transponder, as six hexadecimal digits.  It is worked out from the
+	// N-number, so airplanes on other registers don't have one.
+	ICAOAddress string `json:"icaoAddress,omitempty"`
+
+	// ObservedGeneration is the generation of the spec that the status
+	// reflects.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Conditions describe the state of the airplane: whether it is
+	// assembled, whether its controls are linked, whether another airplane
+	// holds one of its parts, whether it is ready to fly or has a failed
+	// part, and whether it is stuck waiting to be torn down.
+	// +patchMergeKey=type
+	// +patchStrategy=merge
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
+
+	// Airspeed is the indicated airspeed in knots
+	Airspeed int32 `json:"airspeed,omitempty"`
+
+	// WeightOnWheels is the squat switch, indicating the airplane is
+	// resting on its landing gear. When it is not known the airplane is
+	// assumed to be on the ground.
+	WeightOnWheels *bool `json:"weightOnWheels,omitempty"`
+
+	// Flight is the state of the flight dynamics model. It is present
+	// only when the airplane is simulated.
+	Flight *FlightStatus `json:"flight,omitempty"`
+
+	// Gear summarizes the landing gear
+	Gear GearSummary `json:"gear,omitempty"`
+
 	// Rudder names the rudder resource
 	Rudder corev1.ObjectReference `json:"rudder,omitempty"`
 
 	// Pedals names the pedals resource
 	Pedals corev1.ObjectReference `json:"pedals,omitempty"`
+
+	// Yoke names the yoke resource
+	Yoke corev1.ObjectReference `json:"yoke,omitempty"`
+
+	// Aileron names the aileron resource
+	Aileron corev1.ObjectReference `json:"aileron,omitempty"`
+
+	// Elevator names the elevator resource
+	Elevator corev1.ObjectReference `json:"elevator,omitempty"`
+
+	// TrimWheel names the trim wheel resource
+	TrimWheel corev1.ObjectReference `json:"trimWheel,omitempty"`
+
+	// FlapLever names the flap lever resource
+	FlapLever corev1.ObjectReference `json:"flapLever,omitempty"`
+
+	// Flaps names the flaps resource
+	Flaps corev1.ObjectReference `json:"flaps,omitempty"`
+
+	// GearLever names the gear lever resource
+	GearLever corev1.ObjectReference `json:"gearLever,omitempty"`
+
+	// LandingGear names the landing gear resource
+	LandingGear corev1.ObjectReference `json:"landingGear,omitempty"`
 }
 
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
 //+kubebuilder:printcolumn:name="TAILNUMBER",type="string",JSONPath=".spec.tailNumber",description="N-Number registration"
+//+kubebuilder:printcolumn:name="TYPE",type="string",JSONPath=".spec.typeRef.name",description="Aircraft type"
+//+kubebuilder:printcolumn:name="ICAO",type="string",JSONPath=".status.icaoAddress",description="Mode S address",priority=1
+//+kubebuilder:printcolumn:name="AIRSPEED",type="integer",JSONPath=".status.airspeed",description="Indicated airspeed in knots"
+//+kubebuilder:printcolumn:name="ALTITUDE",type="number",JSONPath=".status.flight.altitude",description="Height above the runway in feet"
+//+kubebuilder:printcolumn:name="GEAR",type="string",JSONPath=".status.gear.state",description="State of the landing gear"
+//+kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].status",description="Ready condition"
 //+kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
 
 // Airplane is the Schema for the airplanes API
@@ -0,0 +1,174 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/roehrich-hpe/airplane-sim/pkg/tailnumber"
+)
+
+// log is for logging in this package.
+var airplanelog = logf.Log.WithName("airplane-resource")
+
+// SetupWebhookWithManager sets up the airplane's webhooks with the Manager.
+func (r *Airplane) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(r).
+		WithValidator(&airplaneValidator{Client: mgr.GetClient()}).
+		Complete()
+}
+
+//+kubebuilder:webhook:path=/mutate-play-github-com-v1alpha1-airplane,mutating=true,failurePolicy=fail,sideEffects=None,groups=play.github.com,resources=airplanes,verbs=create;update,versions=v1alpha1,name=mairplane.kb.io,admissionReviewVersions=v1
+
+var _ webhook.Defaulter = &Airplane{}
+
+// Default implements webhook.Defaulter so a webhook will be registered for
+// the type.  Tail numbers are painted in capitals, so that's how they are
+// stored no matter how they were typed.
+func (r *Airplane) Default() {
+	airplanelog.V(1).Info("default", "name", r.Name)
+
+	r.Spec.TailNumber = strings.ToUpper(strings.TrimSpace(r.Spec.TailNumber))
+	if len(r.Spec.Country) == 0 {
+		r.Spec.Country = tailnumber.DefaultCountry
+	}
+}
+
+//+kubebuilder:webhook:path=/validate-play-github-com-v1alpha1-airplane,mutating=false,failurePolicy=fail,sideEffects=None,groups=play.github.com,resources=airplanes,verbs=create;update,versions=v1alpha1,name=vairplane.kb.io,admissionReviewVersions=v1
+
+// airplaneValidator rejects a tail number that breaks the rules of its
+// country's register or that is registered to another airplane anywhere in
+// the cluster, and rejects changing an airplane's tail
+// number to one that another airplane in the namespace already has, since
+// their parts are named after it.
+// +kubebuilder:object:generate=false
+type airplaneValidator struct {
+	Client client.Client
+}
+
+var _ admission.CustomValidator = &airplaneValidator{}
+
+// ValidateCreate implements admission.CustomValidator.
+func (v *airplaneValidator) ValidateCreate(ctx context.Context, obj runtime.Object) error {
+	airplane, ok := obj.(*Airplane)
+	if !ok {
+		return fmt.Errorf("expected an Airplane but got a %T", obj)
+	}
+	airplanelog.V(1).Info("validate create", "name", airplane.Name)
+
+	if err := validateTailNumber(airplane); err != nil {
+		return err
+	}
+	return v.validateRegistration(ctx, airplane)
+}
+
+// ValidateUpdate implements admission.CustomValidator.
+func (v *airplaneValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) error {
+	old, ok := oldObj.(*Airplane)
+	if !ok {
+		return fmt.Errorf("expected an Airplane but got a %T", oldObj)
+	}
+	airplane, ok := newObj.(*Airplane)
+	if !ok {
+		return fmt.Errorf("expected an Airplane but got a %T", newObj)
+	}
+	airplanelog.V(1).Info("validate update", "name", airplane.Name)
+
+	if airplane.Spec.TailNumber == old.Spec.TailNumber && airplane.Spec.Country == old.Spec.Country {
+		return nil
+	}
+	if err := validateTailNumber(airplane); err != nil {
+		return err
+	}
+	if airplane.Spec.TailNumber == old.Spec.TailNumber {
+		return nil
+	}
+	if err := v.validateRegistration(ctx, airplane); err != nil {
+		return err
+	}
+
+	airplanes := &AirplaneList{}
+	if err := v.Client.List(ctx, airplanes, client.InNamespace(airplane.Namespace)); err != nil {
+		return err
+	}
+	for _, other := range airplanes.Items {
+		if other.Name != airplane.Name && other.Spec.TailNumber == airplane.Spec.TailNumber {
+			path := field.NewPath("spec", "tailNumber")
+			return apierrors.NewInvalid(GroupVersion.WithKind("Airplane").GroupKind(), airplane.Name, field.ErrorList{
+				field.Duplicate(path, airplane.Spec.TailNumber),
+			})
+		}
+	}
+	return nil
+}
+
+// validateTailNumber checks the tail number against the rules of the
+// airplane's country's register.
+func validateTailNumber(airplane *Airplane) error {
+	if err := tailnumber.Validate(airplane.Spec.Country, airplane.Spec.TailNumber); err != nil {
+		path := field.NewPath("spec", "tailNumber")
+		return apierrors.NewInvalid(GroupVersion.WithKind("Airplane").GroupKind(), airplane.Name, field.ErrorList{
+			field.Invalid(path, airplane.Spec.TailNumber, err.Error()),
+		})
+	}
+	return nil
+}
+
+// validateRegistration checks that the airplane's tail number isn't
+// registered to another airplane.  A registration whose airplane is gone is
+// stale, and the airplane controller will take it over.
+func (v *airplaneValidator) validateRegistration(ctx context.Context, airplane *Airplane) error {
+	registration := &Registration{}
+	key := client.ObjectKey{Name: strings.ToLower(airplane.Spec.TailNumber)}
+	if err := v.Client.Get(ctx, key, registration); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	holder := registration.Spec.AirplaneRef
+	if holder.Namespace == airplane.Namespace && holder.Name == airplane.Name {
+		return nil
+	}
+
+	other := &Airplane{}
+	if err := v.Client.Get(ctx, client.ObjectKey{Namespace: holder.Namespace, Name: holder.Name}, other); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	if other.UID != holder.UID {
+		return nil
+	}
+
+	path := field.NewPath("spec", "tailNumber")
+	return apierrors.NewInvalid(GroupVersion.WithKind("Airplane").GroupKind(), airplane.Name, field.ErrorList{
+		field.Invalid(path, airplane.Spec.TailNumber, "is registered to another airplane"),
+	})
+}
+
+// ValidateDelete implements admission.CustomValidator.
+func (v *airplaneValidator) ValidateDelete(ctx context.Context, obj runtime.Object) error {
+	return nil
+}
@@ -0,0 +1,163 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"strings"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+var _ = Describe("Airplane Webhook", func() {
+
+	var (
+		key      types.NamespacedName
+		airplane *Airplane
+	)
+
+	BeforeEach(func() {
+		key = types.NamespacedName{
+			Name:      uuid.New().String()[0:8],
+			Namespace: corev1.NamespaceDefault,
+		}
+
+		airplane = &Airplane{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      key.Name,
+				Namespace: key.Namespace,
+			},
+			Spec: AirplaneSpec{
+				TailNumber: strings.ToLower(newTailNumber()),
+			},
+		}
+		Expect(k8sClient.Create(context.TODO(), airplane)).To(Succeed())
+	})
+
+	AfterEach(func() {
+		Expect(k8sClient.Delete(context.TODO(), airplane)).To(Succeed())
+	})
+
+	It("Stores the tail number in capitals", func() {
+		Expect(k8sClient.Get(context.TODO(), key, airplane)).To(Succeed())
+		Expect(airplane.Spec.TailNumber).To(MatchRegexp("^N[A-Z0-9]{5}$"))
+	})
+
+	It("Registers the airplane in the United States by default", func() {
+		Expect(k8sClient.Get(context.TODO(), key, airplane)).To(Succeed())
+		Expect(airplane.Spec.Country).To(Equal("US"))
+	})
+
+	It("Checks the tail number against the country's register", func() {
+		other := &Airplane{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      uuid.New().String()[0:8],
+				Namespace: key.Namespace,
+			},
+			Spec: AirplaneSpec{
+				TailNumber: newTailNumber(),
+				Country:    "GB",
+			},
+		}
+		Expect(k8sClient.Create(context.TODO(), other)).To(Satisfy(apierrors.IsInvalid))
+
+		By("painting on a British mark")
+		other.Spec.TailNumber = "g-ab" + strings.ToLower(newTailNumber()[4:])
+		Expect(k8sClient.Create(context.TODO(), other)).To(Succeed())
+		Expect(other.Spec.TailNumber).To(MatchRegexp("^G-[A-Z]{4}$"))
+
+		By("moving the airplane to a register its tail number doesn't fit")
+		other.Spec.Country = "AU"
+		Expect(k8sClient.Update(context.TODO(), other)).To(Satisfy(apierrors.IsInvalid))
+
+		Expect(k8sClient.Delete(context.TODO(), other)).To(Succeed())
+	})
+
+	It("Rejects changing the tail number to one that is in use", func() {
+		other := &Airplane{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      uuid.New().String()[0:8],
+				Namespace: key.Namespace,
+			},
+			Spec: AirplaneSpec{
+				TailNumber: newTailNumber(),
+			},
+		}
+		Expect(k8sClient.Create(context.TODO(), other)).To(Succeed())
+
+		By("taking the other airplane's tail number")
+		// The webhook may not have seen the other airplane yet.
+		Eventually(func() error {
+			Expect(k8sClient.Get(context.TODO(), key, airplane)).To(Succeed())
+			airplane.Spec.TailNumber = other.Spec.TailNumber
+			return k8sClient.Update(context.TODO(), airplane)
+		}).Should(Satisfy(apierrors.IsInvalid))
+
+		By("taking a tail number nobody has")
+		Expect(k8sClient.Get(context.TODO(), key, airplane)).To(Succeed())
+		airplane.Spec.TailNumber = newTailNumber()
+		Expect(k8sClient.Update(context.TODO(), airplane)).To(Succeed())
+
+		Expect(k8sClient.Delete(context.TODO(), other)).To(Succeed())
+	})
+
+	It("Rejects a tail number registered to another airplane", func() {
+		Expect(k8sClient.Get(context.TODO(), key, airplane)).To(Succeed())
+		registration := &Registration{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: strings.ToLower(airplane.Spec.TailNumber),
+			},
+			Spec: RegistrationSpec{
+				TailNumber: airplane.Spec.TailNumber,
+				AirplaneRef: corev1.ObjectReference{
+					Namespace: airplane.Namespace,
+					Name:      airplane.Name,
+					UID:       airplane.UID,
+				},
+			},
+		}
+		Expect(k8sClient.Create(context.TODO(), registration)).To(Succeed())
+
+		other := &Airplane{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      uuid.New().String()[0:8],
+				Namespace: key.Namespace,
+			},
+			Spec: AirplaneSpec{
+				TailNumber: airplane.Spec.TailNumber,
+			},
+		}
+		// The webhook may not have seen the registration yet.
+		Eventually(func() error {
+			return k8sClient.Create(context.TODO(), other)
+		}).Should(Satisfy(apierrors.IsInvalid))
+
+		By("releasing the registration")
+		Expect(k8sClient.Delete(context.TODO(), registration)).To(Succeed())
+		Eventually(func() error {
+			return k8sClient.Create(context.TODO(), other)
+		}).Should(Succeed())
+		Expect(k8sClient.Delete(context.TODO(), other)).To(Succeed())
+	})
+})
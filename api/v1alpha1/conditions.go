@@ -0,0 +1,71 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+// Condition types
+const (
+	// ConditionAssembled indicates that every part the airplane's type
+	// describes has been built and hooked up to the airplane.
+	ConditionAssembled = "Assembled"
+
+	// ConditionControlsLinked indicates that the controls in the cockpit
+	// are linked to the surfaces they move.
+	ConditionControlsLinked = "ControlsLinked"
+
+	// ConditionReady indicates that the resource is doing what its spec
+	// asks for.
+	ConditionReady = "Ready"
+
+	// ConditionDegraded indicates that a part has failed.
+	ConditionDegraded = "Degraded"
+
+	// ConditionLinkageBroken indicates that a control's linkage has
+	// nothing on the other end to move.
+	ConditionLinkageBroken = "LinkageBroken"
+
+	// ConditionConflict indicates that a part the airplane needs is
+	// already taken by something else of the same name.
+	ConditionConflict = "Conflict"
+
+	// ConditionStuck indicates that a deleted airplane has waited too
+	// long for its surfaces to stow, and its parts have not been
+	// removed.
+	ConditionStuck = "Stuck"
+
+	// ConditionDiverged indicates that a replayed rudder did not follow
+	// the recording.
+	ConditionDiverged = "Diverged"
+)
+
+// Event reasons
+const (
+	// EventPartCreated is recorded when the airplane builds one of its
+	// parts.
+	EventPartCreated = "PartCreated"
+
+	// EventPartLinked is recorded when a part is hooked up to the
+	// airplane.
+	EventPartLinked = "PartLinked"
+
+	// EventRudderMoved is recorded when the rudder reaches a new
+	// deflection.
+	EventRudderMoved = "RudderMoved"
+
+	// EventLinkageConflict is recorded when the pedal linkage loses a
+	// race to update the rudder, and has to try again.
+	EventLinkageConflict = "LinkageConflict"
+)
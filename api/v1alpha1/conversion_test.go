@@ -0,0 +1,227 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/roehrich-hpe/airplane-sim/api/v1beta1"
+)
+
+var _ = Describe("Conversion", func() {
+
+	int32Ptr := func(i int32) *int32 {
+		return &i
+	}
+
+	Context("Rudder", func() {
+		limits := RudderSpec{MinDeflection: int32Ptr(-25), MaxDeflection: int32Ptr(30), SlewRate: 60}
+
+		DescribeTable("round trips through v1beta1",
+			func(position string, deflection *int32, wanted int32) {
+				spec := limits
+				spec.Position = position
+				spec.Deflection = deflection
+				rudder := &Rudder{
+					ObjectMeta: metav1.ObjectMeta{Name: "rudder"},
+					Spec:       spec,
+					Status:     RudderStatus{Position: "left", Deflection: -5, ObservedGeneration: 2},
+				}
+
+				hub := &v1beta1.Rudder{}
+				Expect(rudder.ConvertTo(hub)).To(Succeed())
+				Expect(hub.Spec.Deflection).To(Equal(wanted))
+				Expect(hub.Spec.MaxDeflection).To(HaveValue(Equal(int32(30))))
+				Expect(hub.Status.Deflection).To(Equal(int32(-5)))
+
+				back := &Rudder{}
+				Expect(back.ConvertFrom(hub)).To(Succeed())
+				Expect(back).To(Equal(rudder))
+			},
+			Entry("neutral", "neutral", nil, int32(0)),
+			Entry("left", "left", nil, int32(-25)),
+			Entry("right", "right", nil, int32(30)),
+			Entry("a deflection", "left", int32Ptr(-10), int32(-10)),
+		)
+
+		It("Finds the position of a v1beta1 deflection", func() {
+			hub := &v1beta1.Rudder{
+				Spec:   v1beta1.RudderSpec{Deflection: 12, MinDeflection: int32Ptr(-25), MaxDeflection: int32Ptr(25)},
+				Status: v1beta1.RudderStatus{Deflection: -3},
+			}
+
+			rudder := &Rudder{}
+			Expect(rudder.ConvertFrom(hub)).To(Succeed())
+			Expect(rudder.Spec.Position).To(Equal("right"))
+			Expect(rudder.Spec.Deflection).To(HaveValue(Equal(int32(12))))
+			Expect(rudder.Status.Position).To(Equal("left"))
+
+			back := &v1beta1.Rudder{}
+			Expect(rudder.ConvertTo(back)).To(Succeed())
+			Expect(back).To(Equal(hub))
+		})
+
+		It("Keeps a travel limit of zero", func() {
+			rudder := &Rudder{
+				Spec:   RudderSpec{Position: "right", MinDeflection: int32Ptr(0), SlewRate: 60},
+				Status: RudderStatus{Position: "neutral"},
+			}
+
+			hub := &v1beta1.Rudder{}
+			Expect(rudder.ConvertTo(hub)).To(Succeed())
+			Expect(hub.Spec.MinDeflection).To(HaveValue(BeZero()))
+			Expect(hub.Spec.MaxDeflection).To(BeNil())
+			Expect(hub.Spec.Deflection).To(Equal(DefaultRudderMaxDeflection))
+
+			back := &Rudder{}
+			Expect(back.ConvertFrom(hub)).To(Succeed())
+			Expect(back).To(Equal(rudder))
+		})
+
+		It("Forgets the position once the deflection moves", func() {
+			rudder := &Rudder{Spec: limits}
+			rudder.Spec.Position = "left"
+
+			hub := &v1beta1.Rudder{}
+			Expect(rudder.ConvertTo(hub)).To(Succeed())
+			hub.Spec.Deflection = 4
+
+			back := &Rudder{}
+			Expect(back.ConvertFrom(hub)).To(Succeed())
+			Expect(back.Spec.Position).To(Equal("right"))
+			Expect(back.Spec.Deflection).To(HaveValue(Equal(int32(4))))
+			Expect(back.Annotations).ToNot(HaveKey(RudderPositionAnnotation))
+		})
+	})
+
+	Context("Pedals", func() {
+		DescribeTable("round trips through v1beta1",
+			func(pressed string, travel *int32, wanted int32) {
+				pedals := &Pedals{
+					ObjectMeta: metav1.ObjectMeta{Name: "pedals", Annotations: map[string]string{"keep": "me"}},
+					Spec:       PedalsSpec{Pressed: pressed, Travel: travel},
+					Status:     PedalsStatus{LinkagePosition: "right", LinkageTravel: 40},
+				}
+
+				hub := &v1beta1.Pedals{}
+				Expect(pedals.ConvertTo(hub)).To(Succeed())
+				Expect(hub.Spec.Travel).To(Equal(wanted))
+				Expect(hub.Status.LinkageTravel).To(Equal(int32(40)))
+
+				back := &Pedals{}
+				Expect(back.ConvertFrom(hub)).To(Succeed())
+				Expect(back).To(Equal(pedals))
+			},
+			Entry("none", "none", nil, int32(0)),
+			Entry("left", "left", nil, int32(-100)),
+			Entry("right", "right", nil, int32(100)),
+			Entry("a travel", "right", int32Ptr(40), int32(40)),
+		)
+
+		It("Finds the pressed pedal of a v1beta1 travel", func() {
+			hub := &v1beta1.Pedals{
+				Spec: v1beta1.PedalsSpec{Travel: -30},
+			}
+
+			pedals := &Pedals{}
+			Expect(pedals.ConvertFrom(hub)).To(Succeed())
+			Expect(pedals.Spec.Pressed).To(Equal("left"))
+			Expect(pedals.Spec.Travel).To(HaveValue(Equal(int32(-30))))
+			Expect(pedals.Status.LinkagePosition).To(Equal("neutral"))
+
+			back := &v1beta1.Pedals{}
+			Expect(pedals.ConvertTo(back)).To(Succeed())
+			Expect(back).To(Equal(hub))
+		})
+	})
+
+	Context("Airplane", func() {
+		It("Round trips through v1beta1", func() {
+			weightOnWheels := false
+			airplane := &Airplane{
+				ObjectMeta: metav1.ObjectMeta{Name: "airplane"},
+				Spec: AirplaneSpec{
+					TailNumber: "N238CS",
+					Country:    "US",
+					TypeRef:    &corev1.LocalObjectReference{Name: "c152"},
+					Simulated:  true,
+					Throttle:   80,
+				},
+				Status: AirplaneStatus{
+					ObservedGeneration: 3,
+					TailNumber:         "N238CS",
+					PreviousRegistrations: []PreviousRegistration{
+						{TailNumber: "N12345", Until: metav1.Now()},
+					},
+					ICAOAddress:    "A1E0D5",
+					Airspeed:       95,
+					WeightOnWheels: &weightOnWheels,
+					Flight:         &FlightStatus{Altitude: 1200, TrueAirspeed: 98},
+					Gear:           GearSummary{Lever: "up", State: GearUpLocked},
+					Rudder:         corev1.ObjectReference{Name: "n238cs"},
+					LandingGear:    corev1.ObjectReference{Name: "n238cs"},
+				},
+			}
+
+			hub := &v1beta1.Airplane{}
+			Expect(airplane.ConvertTo(hub)).To(Succeed())
+			Expect(hub.Spec.TailNumber).To(Equal("N238CS"))
+			Expect(hub.Status.Flight.Altitude).To(Equal(1200.0))
+
+			back := &Airplane{}
+			Expect(back.ConvertFrom(hub)).To(Succeed())
+			Expect(back).To(Equal(airplane))
+		})
+	})
+
+	It("Serves both versions from the API server", func() {
+		key := types.NamespacedName{
+			Name:      uuid.New().String()[0:8],
+			Namespace: corev1.NamespaceDefault,
+		}
+		pedals := &Pedals{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      key.Name,
+				Namespace: key.Namespace,
+			},
+			Spec: PedalsSpec{Pressed: "left"},
+		}
+		Expect(k8sClient.Create(context.TODO(), pedals)).To(Succeed())
+
+		stored := &v1beta1.Pedals{}
+		Expect(k8sClient.Get(context.TODO(), key, stored)).To(Succeed())
+		Expect(stored.Spec.Travel).To(Equal(int32(-100)))
+
+		By("pushing the pedals through v1beta1")
+		stored.Spec.Travel = 25
+		Expect(k8sClient.Update(context.TODO(), stored)).To(Succeed())
+
+		Expect(k8sClient.Get(context.TODO(), key, pedals)).To(Succeed())
+		Expect(pedals.Spec.Pressed).To(Equal("right"))
+		Expect(pedals.Spec.Travel).To(HaveValue(Equal(int32(25))))
+
+		Expect(k8sClient.Delete(context.TODO(), pedals)).To(Succeed())
+	})
+})
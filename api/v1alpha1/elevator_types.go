@@ -0,0 +1,105 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ElevatorSpec defines the desired state of Elevator
+type ElevatorSpec struct {
+	// Deflection is the elevator deflection in degrees commanded by the
+	// pitch linkage. Positive values move the trailing edge up, which
+	// raises the nose. Negative values move it down.
+	Deflection int32 `json:"deflection,omitempty"`
+
+	// Trim is the bias, in degrees, that the trim tab adds to the
+	// commanded deflection. It moves the elevator's neutral position.
+	Trim int32 `json:"trim,omitempty"`
+
+	// MinDeflection is the travel limit with the trailing edge down, in
+	// degrees.
+	// +kubebuilder:validation:Maximum:=0
+	// +kubebuilder:default:=-15
+	MinDeflection int32 `json:"minDeflection,omitempty"`
+
+	// MaxDeflection is the travel limit with the trailing edge up, in
+	// degrees.
+	// +kubebuilder:validation:Minimum:=0
+	// +kubebuilder:default:=25
+	MaxDeflection int32 `json:"maxDeflection,omitempty"`
+
+	// MaxTrim is how far the trim tab can bias the elevator in either
+	// direction, in degrees.
+	// +kubebuilder:validation:Minimum:=0
+	// +kubebuilder:default:=10
+	MaxTrim int32 `json:"maxTrim,omitempty"`
+
+	// SlewRate is the speed of the elevator actuator, in degrees per second.
+	// +kubebuilder:validation:Minimum:=1
+	// +kubebuilder:validation:Maximum:=1000
+	// +kubebuilder:default:=60
+	SlewRate int32 `json:"slewRate,omitempty"`
+}
+
+// ElevatorStatus defines the observed state of Elevator
+type ElevatorStatus struct {
+	// Commanded is the deflection, in degrees, that the pitch linkage is
+	// asking for, before trim is applied.
+	Commanded int32 `json:"commanded,omitempty"`
+
+	// Trim is the bias, in degrees, that trim is adding to the commanded
+	// deflection.
+	Trim int32 `json:"trim,omitempty"`
+
+	// Deflection is the current effective deflection of the elevator in
+	// degrees, with trim applied.
+	Deflection int32 `json:"deflection,omitempty"`
+
+	// LastMoved is the time the actuator last advanced the elevator. It
+	// is cleared when the elevator reaches the effective deflection.
+	LastMoved *metav1.MicroTime `json:"lastMoved,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="COMMANDED",type="integer",JSONPath=".status.commanded",description="Commanded deflection of elevator in degrees"
+//+kubebuilder:printcolumn:name="TRIM",type="integer",JSONPath=".status.trim",description="Trim bias of elevator in degrees"
+//+kubebuilder:printcolumn:name="DEFLECTION",type="integer",JSONPath=".status.deflection",description="Effective deflection of elevator in degrees"
+//+kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+
+// Elevator is the Schema for the elevators API
+type Elevator struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ElevatorSpec   `json:"spec"`
+	Status ElevatorStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// ElevatorList contains a list of Elevator
+type ElevatorList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Elevator `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Elevator{}, &ElevatorList{})
+}
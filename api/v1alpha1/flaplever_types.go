@@ -0,0 +1,63 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// FlapLeverSpec defines the desired state of FlapLever
+type FlapLeverSpec struct {
+	// Detent is the flap setting, in degrees, that the lever is placed in.
+	// +kubebuilder:validation:Enum=0;10;20;30
+	// +kubebuilder:default:=0
+	Detent int32 `json:"detent,omitempty"`
+}
+
+// FlapLeverStatus defines the observed state of FlapLever
+type FlapLeverStatus struct {
+	// Detent is the flap setting, in degrees, that the flap linkage is
+	// currently asking for.
+	Detent int32 `json:"detent,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="DETENT",type="integer",JSONPath=".spec.detent",description="Flap setting selected on the lever"
+//+kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+
+// FlapLever is the Schema for the flaplevers API
+type FlapLever struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   FlapLeverSpec   `json:"spec"`
+	Status FlapLeverStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// FlapLeverList contains a list of FlapLever
+type FlapLeverList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []FlapLever `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&FlapLever{}, &FlapLeverList{})
+}
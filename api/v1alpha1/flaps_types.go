@@ -0,0 +1,89 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// FlapsSpec defines the desired state of Flaps
+type FlapsSpec struct {
+	// Position is the desired flap extension in degrees.
+	// +kubebuilder:validation:Minimum:=0
+	Position int32 `json:"position,omitempty"`
+
+	// MaxPosition is the travel limit of the flaps, in degrees.
+	// +kubebuilder:validation:Minimum:=0
+	// +kubebuilder:default:=30
+	MaxPosition int32 `json:"maxPosition,omitempty"`
+
+	// ExtensionRate is the speed of the flap motor, in degrees per second.
+	// +kubebuilder:validation:Minimum:=1
+	// +kubebuilder:validation:Maximum:=1000
+	// +kubebuilder:default:=3
+	ExtensionRate int32 `json:"extensionRate,omitempty"`
+
+	// MaxExtensionSpeed is the maximum flap extended speed, in knots of
+	// indicated airspeed. Above this speed the flaps will not extend, and
+	// flaps that are already extended are retracted.
+	// +kubebuilder:validation:Minimum:=0
+	// +kubebuilder:default:=85
+	MaxExtensionSpeed int32 `json:"maxExtensionSpeed,omitempty"`
+}
+
+// FlapsStatus defines the observed state of Flaps
+type FlapsStatus struct {
+	// Position is the current flap extension in degrees.
+	Position int32 `json:"position,omitempty"`
+
+	// Overspeed indicates that the airplane is above the maximum flap
+	// extended speed, so the flaps are being held retracted.
+	Overspeed bool `json:"overspeed,omitempty"`
+
+	// LastMoved is the time the flap motor last advanced the flaps. It is
+	// cleared when the flaps reach the desired position.
+	LastMoved *metav1.MicroTime `json:"lastMoved,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="DESIRED POSITION",type="integer",JSONPath=".spec.position",description="Desired flap extension in degrees"
+//+kubebuilder:printcolumn:name="CURRENT POSITION",type="integer",JSONPath=".status.position",description="Current flap extension in degrees"
+//+kubebuilder:printcolumn:name="OVERSPEED",type="boolean",JSONPath=".status.overspeed",description="Held retracted above the maximum flap extended speed"
+//+kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+
+// Flaps is the Schema for the flaps API
+type Flaps struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   FlapsSpec   `json:"spec"`
+	Status FlapsStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// FlapsList contains a list of Flaps
+type FlapsList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Flaps `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Flaps{}, &FlapsList{})
+}
@@ -0,0 +1,63 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// GearLeverSpec defines the desired state of GearLever
+type GearLeverSpec struct {
+	// Position is where the gear lever is placed
+	// +kubebuilder:validation:Enum=up;down
+	// +kubebuilder:default:=down
+	Position string `json:"position,omitempty"`
+}
+
+// GearLeverStatus defines the observed state of GearLever
+type GearLeverStatus struct {
+	// Position indicates where the gear linkage is currently
+	// +kubebuilder:validation:Enum=up;down
+	Position string `json:"position,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="POSITION",type="string",JSONPath=".spec.position",description="Position of the gear lever"
+//+kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+
+// GearLever is the Schema for the gearlevers API
+type GearLever struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   GearLeverSpec   `json:"spec"`
+	Status GearLeverStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// GearLeverList contains a list of GearLever
+type GearLeverList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []GearLever `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&GearLever{}, &GearLeverList{})
+}
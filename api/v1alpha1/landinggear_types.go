@@ -0,0 +1,99 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Landing gear states
+const (
+	GearDownLocked = "DownLocked"
+	GearInTransit  = "InTransit"
+	GearUpLocked   = "UpLocked"
+	GearUnsafe     = "Unsafe"
+)
+
+// LandingGearSpec defines the desired state of LandingGear
+type LandingGearSpec struct {
+	// Position is where we want the gear to be
+	// +kubebuilder:validation:Enum=up;down
+	// +kubebuilder:default:=down
+	Position string `json:"position,omitempty"`
+
+	// ExtensionTime is how long the gear takes to go from up-locked to
+	// down-locked.
+	// +kubebuilder:default:="6s"
+	ExtensionTime *metav1.Duration `json:"extensionTime,omitempty"`
+
+	// RetractionTime is how long the gear takes to go from down-locked to
+	// up-locked.
+	// +kubebuilder:default:="8s"
+	RetractionTime *metav1.Duration `json:"retractionTime,omitempty"`
+
+	// Fault simulates gear that fails to lock. While it is set, every
+	// transit ends in the Unsafe state.
+	Fault bool `json:"fault,omitempty"`
+}
+
+// LandingGearStatus defines the observed state of LandingGear
+type LandingGearStatus struct {
+	// State is the state of the gear
+	// +kubebuilder:validation:Enum=DownLocked;InTransit;UpLocked;Unsafe
+	State string `json:"state,omitempty"`
+
+	// Target is the position the gear is moving to, or last moved to.
+	// +kubebuilder:validation:Enum=up;down
+	Target string `json:"target,omitempty"`
+
+	// TransitStarted is the time the current transit began. It is cleared
+	// when the gear stops moving.
+	TransitStarted *metav1.MicroTime `json:"transitStarted,omitempty"`
+
+	// RetractionBlocked indicates that the squat switch is keeping the
+	// gear down because the airplane has weight on its wheels.
+	RetractionBlocked bool `json:"retractionBlocked,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="DESIRED POSITION",type="string",JSONPath=".spec.position",description="Desired position of the gear"
+//+kubebuilder:printcolumn:name="STATE",type="string",JSONPath=".status.state",description="State of the gear"
+//+kubebuilder:printcolumn:name="BLOCKED",type="boolean",JSONPath=".status.retractionBlocked",description="Retraction blocked by the squat switch"
+//+kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+
+// LandingGear is the Schema for the landinggears API
+type LandingGear struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   LandingGearSpec   `json:"spec"`
+	Status LandingGearStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// LandingGearList contains a list of LandingGear
+type LandingGearList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []LandingGear `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&LandingGear{}, &LandingGearList{})
+}
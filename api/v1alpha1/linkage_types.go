@@ -0,0 +1,129 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// FieldReference names a numeric field of a play.github.com object in the
+// same namespace as the linkage.
+type FieldReference struct {
+	// Kind is the kind of the object.
+	// +kubebuilder:validation:Enum=Pedals;Rudder;Yoke;Aileron;Elevator;TrimWheel;FlapLever;Flaps;GearLever;LandingGear;Airplane
+	Kind string `json:"kind"`
+
+	// Name is the name of the object.
+	// +kubebuilder:validation:MinLength:=1
+	Name string `json:"name"`
+
+	// Field is the dotted path to the field, such as
+	// "status.linkageTravel". A field that is not set reads as zero.
+	// +kubebuilder:validation:Pattern:=`^[a-z][A-Za-z0-9]*(\.[a-z][A-Za-z0-9]*)+$`
+	Field string `json:"field"`
+}
+
+// LinkageSpec defines the desired state of Linkage
+type LinkageSpec struct {
+	// SourceRef is the field the linkage reads, usually the status of a
+	// control in the cockpit.
+	SourceRef FieldReference `json:"sourceRef"`
+
+	// TargetRef is the field the linkage drives. It must be a spec field,
+	// usually the desired position of a control surface, and the linkage
+	// is broken while a built-in linkage also drives that field.
+	TargetRef FieldReference `json:"targetRef"`
+
+	// Gain scales the source value onto the target. A gain of zero holds
+	// the target at zero.
+	// +kubebuilder:default:=1
+	// +optional
+	Gain *float64 `json:"gain,omitempty"`
+
+	// DeadBand is how far the source may move either side of zero before
+	// the target follows it.
+	// +kubebuilder:validation:Minimum:=0
+	DeadBand float64 `json:"deadBand,omitempty"`
+
+	// Reversed drives the target the opposite way, as a linkage that was
+	// rigged backwards would.
+	Reversed bool `json:"reversed,omitempty"`
+
+	// Min is the lowest value the linkage will drive the target to.
+	// +optional
+	Min *float64 `json:"min,omitempty"`
+
+	// Max is the highest value the linkage will drive the target to.
+	// +optional
+	Max *float64 `json:"max,omitempty"`
+}
+
+// LinkageStatus defines the observed state of Linkage
+type LinkageStatus struct {
+	// Input is the value last read from the source.
+	Input float64 `json:"input,omitempty"`
+
+	// Output is the value last written to the target.
+	Output float64 `json:"output,omitempty"`
+
+	// ObservedGeneration is the generation of the spec that the status
+	// reflects.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Conditions describe the state of the linkage. LinkageBroken is true
+	// while the source or target can't be found or read, and Ready is true
+	// once the target has been set to follow the source.
+	// +patchMergeKey=type
+	// +patchStrategy=merge
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="SOURCE",type="string",JSONPath=".spec.sourceRef.name",description="Name of the source object"
+//+kubebuilder:printcolumn:name="TARGET",type="string",JSONPath=".spec.targetRef.name",description="Name of the target object"
+//+kubebuilder:printcolumn:name="INPUT",type="number",JSONPath=".status.input",description="Value read from the source"
+//+kubebuilder:printcolumn:name="OUTPUT",type="number",JSONPath=".status.output",description="Value written to the target"
+//+kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].status",description="Ready condition"
+//+kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+
+// Linkage is the Schema for the linkages API.  A linkage connects a field of
+// one part to a field of another, so that any input can be rigged to any
+// surface.
+type Linkage struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   LinkageSpec   `json:"spec"`
+	Status LinkageStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// LinkageList contains a list of Linkage
+type LinkageList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Linkage `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Linkage{}, &LinkageList{})
+}
@@ -0,0 +1,93 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	"github.com/roehrich-hpe/airplane-sim/api/v1beta1"
+)
+
+// PedalsPressedAnnotation keeps v1alpha1 pedals' Pressed while they are
+// stored as v1beta1, which has only a travel.  It is only set when the pedals
+// were pressed without a travel, so that the pedals read back the way they
+// were written.
+const PedalsPressedAnnotation = "play.github.com/v1alpha1-pressed"
+
+// ConvertTo converts these Pedals to the Hub version (v1beta1).  A pressed
+// pedal is pushed all the way in.
+func (src *Pedals) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*v1beta1.Pedals)
+
+	dst.ObjectMeta = src.ObjectMeta
+	if src.Spec.Travel != nil {
+		dst.Spec.Travel = *src.Spec.Travel
+	} else {
+		dst.Spec.Travel = pressedTravel(src.Spec.Pressed)
+		setAnnotation(&dst.ObjectMeta, PedalsPressedAnnotation, src.Spec.Pressed)
+	}
+
+	dst.Status.LinkageTravel = src.Status.LinkageTravel
+	dst.Status.ObservedGeneration = src.Status.ObservedGeneration
+	dst.Status.Conditions = src.Status.Conditions
+	return nil
+}
+
+// ConvertFrom converts from the Hub version (v1beta1) to this version.  The
+// pressed pedal is the one on the side the pedals are pushed toward.
+func (dst *Pedals) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*v1beta1.Pedals)
+
+	dst.ObjectMeta = src.ObjectMeta
+	pressed, ok := popAnnotation(&dst.ObjectMeta, PedalsPressedAnnotation)
+	if ok && pressedTravel(pressed) == src.Spec.Travel {
+		dst.Spec.Pressed = pressed
+		dst.Spec.Travel = nil
+	} else {
+		travel := src.Spec.Travel
+		dst.Spec.Pressed = travelPressed(travel)
+		dst.Spec.Travel = &travel
+	}
+
+	dst.Status.LinkagePosition = deflectionPosition(src.Status.LinkageTravel)
+	dst.Status.LinkageTravel = src.Status.LinkageTravel
+	dst.Status.ObservedGeneration = src.Status.ObservedGeneration
+	dst.Status.Conditions = src.Status.Conditions
+	return nil
+}
+
+// pressedTravel is the travel of a pressed pedal.
+func pressedTravel(pressed string) int32 {
+	switch pressed {
+	case "left":
+		return -100
+	case "right":
+		return 100
+	}
+	return 0
+}
+
+// travelPressed is the pedal that is pressed for a travel.
+func travelPressed(travel int32) string {
+	switch {
+	case travel < 0:
+		return "left"
+	case travel > 0:
+		return "right"
+	}
+	return "none"
+}
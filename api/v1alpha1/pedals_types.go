@@ -22,10 +22,20 @@ import (
 
 // PedalsSpec defines the desired state of Pedals
 type PedalsSpec struct {
-	// Pressed indicates which pedal is pressed
+	// Pressed indicates which pedal is pressed. It is used when Travel is
+	// not specified, and means the pedal is pushed all the way in.
 	// +kubebuilder:validation:Enum=none;left;right
 	// +kubebuilder:default:=none
 	Pressed string `json:"pressed,omitempty"`
+
+	// Travel is how far the pedals are pushed, as a percentage of full
+	// travel. Negative values press the left pedal, positive values press
+	// the right pedal. When this is specified it takes precedence over
+	// Pressed.
+	// +kubebuilder:validation:Minimum:=-100
+	// +kubebuilder:validation:Maximum:=100
+	// +optional
+	Travel *int32 `json:"travel,omitempty"`
 }
 
 // PedalsStatus defines the observed state of Pedals
@@ -34,11 +44,32 @@ type PedalsStatus struct {
 	// +kubebuilder:validation:Enum=neutral;left;right
 	// +kubebuilder:default:=neutral
 	LinkagePosition string `json:"linkagePosition,omitempty"`
+
+	// LinkageTravel indicates how far the pedal linkage has travelled, as
+	// a percentage of full travel. Negative values are to the left.
+	LinkageTravel int32 `json:"linkageTravel,omitempty"`
+
+	// ObservedGeneration is the generation of the spec that the status
+	// reflects.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Conditions describe the state of the pedals. ControlsLinked is true
+	// while the pedal linkage is connected to the rudder, and LinkageBroken
+	// is true while it isn't. Ready is true when the rudder has been set to
+	// follow the pedals.
+	// +patchMergeKey=type
+	// +patchStrategy=merge
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
 }
 
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
 //+kubebuilder:printcolumn:name="PRESSED",type="string",JSONPath=".spec.pressed",description="Indicates which pedal is pressed"
+//+kubebuilder:printcolumn:name="TRAVEL",type="integer",JSONPath=".status.linkageTravel",description="Percentage of pedal linkage travel"
+//+kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].status",description="Ready condition"
 //+kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
 
 // Pedals is the Schema for the pedals API
@@ -0,0 +1,48 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+)
+
+// log is for logging in this package.
+var pedalslog = logf.Log.WithName("pedals-resource")
+
+// SetupWebhookWithManager sets up the pedals' webhooks with the Manager.
+func (r *Pedals) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(r).
+		Complete()
+}
+
+//+kubebuilder:webhook:path=/mutate-play-github-com-v1alpha1-pedals,mutating=true,failurePolicy=fail,sideEffects=None,groups=play.github.com,resources=pedals,verbs=create;update,versions=v1alpha1,name=mpedals.kb.io,admissionReviewVersions=v1
+
+var _ webhook.Defaulter = &Pedals{}
+
+// Default implements webhook.Defaulter so a webhook will be registered for
+// the type.  It fills in the same values as the CRD's defaults, which the API
+// server skips when the spec is left out altogether.
+func (r *Pedals) Default() {
+	pedalslog.V(1).Info("default", "name", r.Name)
+
+	if len(r.Spec.Pressed) == 0 {
+		r.Spec.Pressed = "none"
+	}
+}
@@ -0,0 +1,52 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+var _ = Describe("Pedals Webhook", func() {
+
+	It("Fills in the defaults for a nil spec", func() {
+		key := types.NamespacedName{
+			Name:      uuid.New().String()[0:8],
+			Namespace: corev1.NamespaceDefault,
+		}
+
+		nilSpec := &unstructured.Unstructured{}
+		nilSpec.SetGroupVersionKind(GroupVersion.WithKind("Pedals"))
+		nilSpec.SetName(key.Name)
+		nilSpec.SetNamespace(key.Namespace)
+		nilSpec.Object["spec"] = nil
+		Expect(k8sClient.Create(context.TODO(), nilSpec)).To(Succeed())
+
+		pedals := &Pedals{}
+		Expect(k8sClient.Get(context.TODO(), key, pedals)).To(Succeed())
+		Expect(pedals.Spec.Pressed).To(Equal("none"))
+
+		Expect(k8sClient.Delete(context.TODO(), pedals)).To(Succeed())
+	})
+})
@@ -0,0 +1,64 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// RegistrationSpec defines the desired state of Registration
+type RegistrationSpec struct {
+	// TailNumber is the registration mark.  The registration is named
+	// after it, in lower case, so there can be only one of each.
+	// +kubebuilder:validation:Pattern:="^(N[1-9]([0-9]{0,4}|[0-9]{0,3}[A-HJ-NP-Z]|[0-9]{0,2}[A-HJ-NP-Z]{2})|[A-Z]{1,2}-[A-Z0-9]{3,5})$"
+	TailNumber string `json:"tailNumber"`
+
+	// AirplaneRef is the airplane that holds the registration.
+	AirplaneRef corev1.ObjectReference `json:"airplaneRef"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:resource:scope=Cluster
+//+kubebuilder:printcolumn:name="TAILNUMBER",type="string",JSONPath=".spec.tailNumber",description="Registration mark"
+//+kubebuilder:printcolumn:name="NAMESPACE",type="string",JSONPath=".spec.airplaneRef.namespace",description="Namespace of the airplane"
+//+kubebuilder:printcolumn:name="AIRPLANE",type="string",JSONPath=".spec.airplaneRef.name",description="Airplane holding the registration"
+//+kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+
+// Registration is the Schema for the registrations API. It is the
+// cluster-wide record of which airplane holds a tail number.  The airplane
+// controller claims it when the airplane is created and releases it when
+// the airplane is deleted or re-registered.
+type Registration struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec RegistrationSpec `json:"spec"`
+}
+
+//+kubebuilder:object:root=true
+
+// RegistrationList contains a list of Registration
+type RegistrationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Registration `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Registration{}, &RegistrationList{})
+}
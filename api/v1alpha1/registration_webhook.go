@@ -0,0 +1,75 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+)
+
+// log is for logging in this package.
+var registrationlog = logf.Log.WithName("registration-resource")
+
+// SetupWebhookWithManager sets up the registration's webhooks with the
+// Manager.
+func (r *Registration) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(r).
+		Complete()
+}
+
+//+kubebuilder:webhook:path=/validate-play-github-com-v1alpha1-registration,mutating=false,failurePolicy=fail,sideEffects=None,groups=play.github.com,resources=registrations,verbs=create;update,versions=v1alpha1,name=vregistration.kb.io,admissionReviewVersions=v1
+
+var _ webhook.Validator = &Registration{}
+
+// ValidateCreate implements webhook.Validator.  A registration must be named
+// after its tail number, or the name would not keep it unique.
+func (r *Registration) ValidateCreate() error {
+	registrationlog.V(1).Info("validate create", "name", r.Name)
+
+	if r.Name != strings.ToLower(r.Spec.TailNumber) {
+		return r.invalid(field.Invalid(field.NewPath("metadata", "name"), r.Name, "must be the tail number in lower case"))
+	}
+	return nil
+}
+
+// ValidateUpdate implements webhook.Validator.  The tail number can't
+// change; re-registering an airplane releases the old registration and
+// claims a new one.
+func (r *Registration) ValidateUpdate(old runtime.Object) error {
+	registrationlog.V(1).Info("validate update", "name", r.Name)
+
+	if r.Spec.TailNumber != old.(*Registration).Spec.TailNumber {
+		return r.invalid(field.Forbidden(field.NewPath("spec", "tailNumber"), "the tail number can't be changed"))
+	}
+	return nil
+}
+
+// ValidateDelete implements webhook.Validator.
+func (r *Registration) ValidateDelete() error {
+	return nil
+}
+
+func (r *Registration) invalid(err *field.Error) error {
+	return apierrors.NewInvalid(GroupVersion.WithKind("Registration").GroupKind(), r.Name, field.ErrorList{err})
+}
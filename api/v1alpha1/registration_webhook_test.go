@@ -0,0 +1,65 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"strings"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("Registration Webhook", func() {
+
+	var registration *Registration
+
+	BeforeEach(func() {
+		tailNumber := newTailNumber()
+		registration = &Registration{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: strings.ToLower(tailNumber),
+			},
+			Spec: RegistrationSpec{
+				TailNumber: tailNumber,
+				AirplaneRef: corev1.ObjectReference{
+					Namespace: corev1.NamespaceDefault,
+					Name:      uuid.New().String()[0:8],
+				},
+			},
+		}
+	})
+
+	It("Rejects a registration that isn't named after its tail number", func() {
+		registration.Name = "n" + uuid.New().String()[0:5]
+		Expect(k8sClient.Create(context.TODO(), registration)).To(Satisfy(apierrors.IsInvalid))
+	})
+
+	It("Rejects changing the tail number", func() {
+		Expect(k8sClient.Create(context.TODO(), registration)).To(Succeed())
+
+		registration.Spec.TailNumber = newTailNumber()
+		Expect(k8sClient.Update(context.TODO(), registration)).To(Satisfy(apierrors.IsInvalid))
+
+		Expect(k8sClient.Delete(context.TODO(), registration)).To(Succeed())
+	})
+})
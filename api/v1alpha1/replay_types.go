@@ -0,0 +1,150 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Replay phases
+const (
+	ReplayPending  = "Pending"
+	ReplayPlaying  = "Playing"
+	ReplayFinished = "Finished"
+)
+
+// TimelineSource says where a recorded timeline is kept.
+type TimelineSource struct {
+	// ConfigMapRef names the ConfigMap, in the replay's namespace, that
+	// holds the timeline.  The flight data recorder's ConfigMaps can be
+	// used as they are.
+	ConfigMapRef corev1.LocalObjectReference `json:"configMapRef"`
+
+	// Key is the key of the timeline in the ConfigMap.  A key ending in
+	// ".csv" is read as CSV, anything else as JSON.
+	// +kubebuilder:default:="flightdata.json"
+	Key string `json:"key,omitempty"`
+}
+
+// ReplaySpec defines the desired state of Replay
+type ReplaySpec struct {
+	// AirplaneRef names the airplane, in the replay's namespace, that the
+	// timeline is played back on.
+	AirplaneRef corev1.LocalObjectReference `json:"airplaneRef"`
+
+	// Timeline is the recording to play back.
+	Timeline TimelineSource `json:"timeline"`
+
+	// Tolerance is how far, in degrees, the rudder may be from the
+	// recording before it counts as a divergence.
+	// +kubebuilder:validation:Minimum:=0
+	// +kubebuilder:default:=2
+	Tolerance int32 `json:"tolerance,omitempty"`
+}
+
+// ReplayDivergence is a point in the timeline where the rudder did not do
+// what the recording says it did.
+type ReplayDivergence struct {
+	// Offset is how far into the timeline the divergence was seen.
+	Offset metav1.Duration `json:"offset"`
+
+	// Expected is the recorded rudder deflection, in degrees.
+	Expected int32 `json:"expected"`
+
+	// Actual is the rudder deflection in the replay, in degrees.
+	Actual int32 `json:"actual"`
+}
+
+// ReplayStatus defines the observed state of Replay
+type ReplayStatus struct {
+	// Phase is how far along the replay is.
+	// +kubebuilder:validation:Enum=Pending;Playing;Finished
+	Phase string `json:"phase,omitempty"`
+
+	// StartTime is the simulation time that the replay began.
+	StartTime *metav1.MicroTime `json:"startTime,omitempty"`
+
+	// Samples is how many samples the timeline holds.
+	Samples int32 `json:"samples,omitempty"`
+
+	// NextSample is the index of the next sample to play.
+	NextSample int32 `json:"nextSample,omitempty"`
+
+	// Compared is how many samples the rudder was compared against.
+	Compared int32 `json:"compared,omitempty"`
+
+	// DivergenceCount is how many of the compared samples the rudder
+	// did not match.
+	DivergenceCount int32 `json:"divergenceCount,omitempty"`
+
+	// MaxDivergence is the furthest, in degrees, that the rudder was
+	// from the recording.
+	MaxDivergence int32 `json:"maxDivergence,omitempty"`
+
+	// Divergences are the first few divergences.
+	Divergences []ReplayDivergence `json:"divergences,omitempty"`
+
+	// ObservedGeneration is the generation of the spec that the status
+	// reflects.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Conditions are Ready, which is True once the replay has finished,
+	// and Diverged, which is True if the rudder did not follow the
+	// recording.
+	// +patchMergeKey=type
+	// +patchStrategy=merge
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="AIRPLANE",type="string",JSONPath=".spec.airplaneRef.name",description="Airplane the timeline is played back on"
+//+kubebuilder:printcolumn:name="PHASE",type="string",JSONPath=".status.phase",description="How far along the replay is"
+//+kubebuilder:printcolumn:name="COMPARED",type="integer",JSONPath=".status.compared",description="Samples compared",priority=1
+//+kubebuilder:printcolumn:name="DIVERGED",type="integer",JSONPath=".status.divergenceCount",description="Samples the rudder did not match"
+//+kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+
+// Replay is the Schema for the replays API.  A replay plays the recorded
+// inputs of a timeline back on an airplane's pedals, yoke, trim wheel, flap
+// lever and gear lever, at the recorded times on the simulation clock, and
+// checks that the rudder follows the recording.  An input the timeline
+// leaves out is played as neutral, except for the gear lever, which stays
+// where it is.
+type Replay struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ReplaySpec   `json:"spec,omitempty"`
+	Status ReplayStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// ReplayList contains a list of Replay
+type ReplayList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Replay `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Replay{}, &ReplayList{})
+}
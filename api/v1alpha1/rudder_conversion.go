@@ -0,0 +1,104 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	"github.com/roehrich-hpe/airplane-sim/api/v1beta1"
+)
+
+// RudderPositionAnnotation keeps a v1alpha1 rudder's Position while it is
+// stored as v1beta1, which has only a deflection.  It is only set when the
+// rudder was positioned without a deflection, so that the rudder reads back
+// the way it was written.
+const RudderPositionAnnotation = "play.github.com/v1alpha1-position"
+
+// ConvertTo converts this Rudder to the Hub version (v1beta1).  A rudder
+// that only has a Position gets the deflection of that position.
+func (src *Rudder) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*v1beta1.Rudder)
+
+	dst.ObjectMeta = src.ObjectMeta
+	dst.Spec.MinDeflection = src.Spec.MinDeflection
+	dst.Spec.MaxDeflection = src.Spec.MaxDeflection
+	dst.Spec.SlewRate = src.Spec.SlewRate
+	if src.Spec.Deflection != nil {
+		dst.Spec.Deflection = *src.Spec.Deflection
+	} else {
+		minDeflection, maxDeflection := src.Spec.Limits()
+		dst.Spec.Deflection = positionDeflection(src.Spec.Position, minDeflection, maxDeflection)
+		setAnnotation(&dst.ObjectMeta, RudderPositionAnnotation, src.Spec.Position)
+	}
+
+	dst.Status.Deflection = src.Status.Deflection
+	dst.Status.LastMoved = src.Status.LastMoved
+	dst.Status.ObservedGeneration = src.Status.ObservedGeneration
+	dst.Status.Conditions = src.Status.Conditions
+	return nil
+}
+
+// ConvertFrom converts from the Hub version (v1beta1) to this version.  The
+// positions are the sides the deflections are on.
+func (dst *Rudder) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*v1beta1.Rudder)
+
+	dst.ObjectMeta = src.ObjectMeta
+	dst.Spec.MinDeflection = src.Spec.MinDeflection
+	dst.Spec.MaxDeflection = src.Spec.MaxDeflection
+	dst.Spec.SlewRate = src.Spec.SlewRate
+	minDeflection, maxDeflection := src.Spec.Limits()
+	position, ok := popAnnotation(&dst.ObjectMeta, RudderPositionAnnotation)
+	if ok && positionDeflection(position, minDeflection, maxDeflection) == src.Spec.Deflection {
+		dst.Spec.Position = position
+		dst.Spec.Deflection = nil
+	} else {
+		deflection := src.Spec.Deflection
+		dst.Spec.Position = deflectionPosition(deflection)
+		dst.Spec.Deflection = &deflection
+	}
+
+	dst.Status.Position = deflectionPosition(src.Status.Deflection)
+	dst.Status.Deflection = src.Status.Deflection
+	dst.Status.LastMoved = src.Status.LastMoved
+	dst.Status.ObservedGeneration = src.Status.ObservedGeneration
+	dst.Status.Conditions = src.Status.Conditions
+	return nil
+}
+
+// positionDeflection is the deflection of a rudder Position, which is at the
+// travel limit on the requested side.
+func positionDeflection(position string, minDeflection, maxDeflection int32) int32 {
+	switch position {
+	case "left":
+		return minDeflection
+	case "right":
+		return maxDeflection
+	}
+	return 0
+}
+
+// deflectionPosition is the side a deflection is on.
+func deflectionPosition(deflection int32) string {
+	switch {
+	case deflection < 0:
+		return "left"
+	case deflection > 0:
+		return "right"
+	}
+	return "neutral"
+}
@@ -23,12 +23,59 @@ import (
 // EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
 // NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
 
+// The travel limits of a rudder that doesn't give its own.
+const (
+	DefaultRudderMinDeflection int32 = -25
+	DefaultRudderMaxDeflection int32 = 25
+)
+
 // RudderSpec defines the desired state of Rudder
 type RudderSpec struct {
-	// Position indicates where we want the rudder to be placed
+	// Position indicates where we want the rudder to be placed. It is used
+	// when Deflection is not specified, and places the rudder at neutral or
+	// at the travel limit on the requested side.
 	// +kubebuilder:validation:Enum=neutral;left;right
 	// +kubebuilder:default:=neutral
 	Position string `json:"position,omitempty"`
+
+	// Deflection is the desired rudder deflection in degrees. Negative
+	// values are to the left, positive values are to the right. When this
+	// is specified it takes precedence over Position.
+	// +optional
+	Deflection *int32 `json:"deflection,omitempty"`
+
+	// MinDeflection is the travel limit to the left, in degrees. Zero
+	// keeps the rudder from moving left.
+	// +kubebuilder:validation:Maximum:=0
+	// +kubebuilder:default:=-25
+	// +optional
+	MinDeflection *int32 `json:"minDeflection,omitempty"`
+
+	// MaxDeflection is the travel limit to the right, in degrees. Zero
+	// keeps the rudder from moving right.
+	// +kubebuilder:validation:Minimum:=0
+	// +kubebuilder:default:=25
+	// +optional
+	MaxDeflection *int32 `json:"maxDeflection,omitempty"`
+
+	// SlewRate is the speed of the rudder actuator, in degrees per second.
+	// +kubebuilder:validation:Minimum:=1
+	// +kubebuilder:validation:Maximum:=1000
+	// +kubebuilder:default:=60
+	SlewRate int32 `json:"slewRate,omitempty"`
+}
+
+// Limits returns the travel limits, in degrees.  A limit that isn't given
+// has its default.
+func (s *RudderSpec) Limits() (int32, int32) {
+	minDeflection, maxDeflection := DefaultRudderMinDeflection, DefaultRudderMaxDeflection
+	if s.MinDeflection != nil {
+		minDeflection = *s.MinDeflection
+	}
+	if s.MaxDeflection != nil {
+		maxDeflection = *s.MaxDeflection
+	}
+	return minDeflection, maxDeflection
 }
 
 // RudderStatus defines the observed state of Rudder
@@ -37,12 +84,34 @@ type RudderStatus struct {
 	// +kubebuilder:validation:Enum=neutral;left;right
 	// +kubebuilder:default:=neutral
 	Position string `json:"position,omitempty"`
+
+	// Deflection is the current rudder deflection in degrees.
+	Deflection int32 `json:"deflection,omitempty"`
+
+	// LastMoved is the time the actuator last advanced the rudder. It is
+	// cleared when the rudder reaches the desired deflection.
+	LastMoved *metav1.MicroTime `json:"lastMoved,omitempty"`
+
+	// ObservedGeneration is the generation of the spec that the status
+	// reflects.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Conditions describe the state of the rudder. Ready is true once the
+	// rudder has reached the desired deflection.
+	// +patchMergeKey=type
+	// +patchStrategy=merge
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
 }
 
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
 //+kubebuilder:printcolumn:name="DESIRED POSITION",type="string",JSONPath=".spec.position",description="Desired position of rudder"
 //+kubebuilder:printcolumn:name="CURRENT POSITION",type="string",JSONPath=".status.position",description="Current position of rudder"
+//+kubebuilder:printcolumn:name="DEFLECTION",type="integer",JSONPath=".status.deflection",description="Current deflection of rudder in degrees"
+//+kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].status",description="Ready condition"
 //+kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
 
 // Rudder is the Schema for the rudders API
@@ -0,0 +1,237 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/rest"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// LinkageFieldManager is the field manager the linkages use when they move
+// a surface.  It is only a hint; the webhook decides whether an update came
+// from a linkage by the user that made it.
+const LinkageFieldManager = "airplane-sim-linkage"
+
+// log is for logging in this package.
+var rudderlog = logf.Log.WithName("rudder-resource")
+
+// SetupWebhookWithManager sets up the rudder's webhooks with the Manager.
+func (r *Rudder) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	linkageUser, err := configUsername(mgr.GetConfig())
+	if err != nil {
+		return err
+	}
+	mgr.GetWebhookServer().Register("/validate-play-github-com-v1alpha1-rudder", &webhook.Admission{
+		Handler: &rudderValidator{
+			Client:      mgr.GetClient(),
+			linkageUser: linkageUser,
+			decoder:     mustNewDecoder(mgr),
+		},
+	})
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(r).
+		Complete()
+}
+
+//+kubebuilder:webhook:path=/mutate-play-github-com-v1alpha1-rudder,mutating=true,failurePolicy=fail,sideEffects=None,groups=play.github.com,resources=rudders,verbs=create;update,versions=v1alpha1,name=mrudder.kb.io,admissionReviewVersions=v1
+
+var _ webhook.Defaulter = &Rudder{}
+
+// Default implements webhook.Defaulter so a webhook will be registered for
+// the type.  It fills in the same values as the CRD's defaults, which the API
+// server skips when the spec is left out altogether.
+func (r *Rudder) Default() {
+	rudderlog.V(1).Info("default", "name", r.Name)
+
+	if len(r.Spec.Position) == 0 {
+		r.Spec.Position = "neutral"
+	}
+	if r.Spec.MinDeflection == nil {
+		minDeflection := DefaultRudderMinDeflection
+		r.Spec.MinDeflection = &minDeflection
+	}
+	if r.Spec.MaxDeflection == nil {
+		maxDeflection := DefaultRudderMaxDeflection
+		r.Spec.MaxDeflection = &maxDeflection
+	}
+	if r.Spec.SlewRate == 0 {
+		r.Spec.SlewRate = 60
+	}
+}
+
+//+kubebuilder:webhook:path=/validate-play-github-com-v1alpha1-rudder,mutating=false,failurePolicy=fail,sideEffects=None,groups=play.github.com,resources=rudders,verbs=update,versions=v1alpha1,name=vrudder.kb.io,admissionReviewVersions=v1
+
+// rudderValidator rejects moving a rudder by hand while a linkage drives it,
+// since the linkage would only move it back.  The travel limits and slew
+// rate may still be changed.  It looks at the user that made the request,
+// which the webhook.Validator interface doesn't offer.  The linkages run in
+// the manager, so only the manager's own user may move a driven rudder.
+// +kubebuilder:object:generate=false
+type rudderValidator struct {
+	Client      client.Client
+	linkageUser string
+	decoder     *admission.Decoder
+}
+
+// Handle implements admission.Handler.
+func (v *rudderValidator) Handle(ctx context.Context, req admission.Request) admission.Response {
+	if req.Operation != admissionv1.Update {
+		return admission.Allowed("")
+	}
+
+	rudder, old := &Rudder{}, &Rudder{}
+	if err := v.decoder.DecodeRaw(req.Object, rudder); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+	if err := v.decoder.DecodeRaw(req.OldObject, old); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+	rudderlog.V(1).Info("validate update", "name", rudder.Name)
+
+	if rudder.Spec.Position == old.Spec.Position && equalDeflection(rudder.Spec.Deflection, old.Spec.Deflection) {
+		return admission.Allowed("")
+	}
+
+	options := &metav1.UpdateOptions{}
+	if len(req.Options.Raw) > 0 {
+		if err := json.Unmarshal(req.Options.Raw, options); err != nil {
+			return admission.Errored(http.StatusBadRequest, err)
+		}
+	}
+	if len(v.linkageUser) > 0 && req.UserInfo.Username == v.linkageUser {
+		return admission.Allowed("")
+	}
+	if options.FieldManager == LinkageFieldManager {
+		rudderlog.Info("update claims to be from a linkage", "name", rudder.Name, "user", req.UserInfo.Username)
+	}
+
+	driver, err := v.rudderDriver(ctx, rudder)
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	if len(driver) > 0 {
+		return admission.Denied(fmt.Sprintf("rudder %s is driven by %s; move the controls instead", rudder.Name, driver))
+	}
+	return admission.Allowed("")
+}
+
+// rudderDriver describes what drives the rudder, either the pedals with the
+// same name or a Linkage that targets it.  It is empty when nothing does.
+func (v *rudderValidator) rudderDriver(ctx context.Context, rudder *Rudder) (string, error) {
+	pedals := &Pedals{}
+	if err := v.Client.Get(ctx, client.ObjectKeyFromObject(rudder), pedals); err == nil {
+		return "pedals " + pedals.Name, nil
+	} else if !apierrors.IsNotFound(err) {
+		return "", err
+	}
+
+	linkages := &LinkageList{}
+	if err := v.Client.List(ctx, linkages, client.InNamespace(rudder.Namespace)); err != nil {
+		return "", err
+	}
+	for _, linkage := range linkages.Items {
+		ref := linkage.Spec.TargetRef
+		if ref.Kind == "Rudder" && ref.Name == rudder.Name {
+			return "linkage " + linkage.Name, nil
+		}
+	}
+	return "", nil
+}
+
+// configUsername returns the user the API server sees for a client config,
+// read from its client certificate or its service account token.  It is
+// empty when the config has neither.
+func configUsername(config *rest.Config) (string, error) {
+	if len(config.Username) > 0 {
+		return config.Username, nil
+	}
+
+	certData := config.CertData
+	if len(certData) == 0 && len(config.CertFile) > 0 {
+		data, err := os.ReadFile(config.CertFile)
+		if err != nil {
+			return "", err
+		}
+		certData = data
+	}
+	if block, _ := pem.Decode(certData); block != nil {
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return "", err
+		}
+		return cert.Subject.CommonName, nil
+	}
+
+	token := config.BearerToken
+	if len(token) == 0 && len(config.BearerTokenFile) > 0 {
+		data, err := os.ReadFile(config.BearerTokenFile)
+		if err != nil {
+			return "", err
+		}
+		token = strings.TrimSpace(string(data))
+	}
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return "", nil
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return "", err
+	}
+	claims := struct {
+		Subject string `json:"sub"`
+	}{}
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return "", err
+	}
+	return claims.Subject, nil
+}
+
+// equalDeflection compares two optional deflections.
+func equalDeflection(a, b *int32) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// mustNewDecoder returns a decoder for the manager's scheme.  It only fails
+// when the scheme is nil.
+func mustNewDecoder(mgr ctrl.Manager) *admission.Decoder {
+	decoder, err := admission.NewDecoder(mgr.GetScheme())
+	if err != nil {
+		panic(err)
+	}
+	return decoder
+}
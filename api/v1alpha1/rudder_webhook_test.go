@@ -0,0 +1,152 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("Rudder Webhook", func() {
+
+	var (
+		key    types.NamespacedName
+		rudder *Rudder
+	)
+
+	BeforeEach(func() {
+		key = types.NamespacedName{
+			Name:      uuid.New().String()[0:8],
+			Namespace: corev1.NamespaceDefault,
+		}
+
+		rudder = &Rudder{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      key.Name,
+				Namespace: key.Namespace,
+			},
+		}
+	})
+
+	AfterEach(func() {
+		Expect(client.IgnoreNotFound(k8sClient.Delete(context.TODO(), rudder))).To(Succeed())
+	})
+
+	// moveRudder tries to move the rudder to the other side, as the given
+	// client's user.
+	moveRudder := func(c client.Client, opts ...client.UpdateOption) error {
+		Expect(c.Get(context.TODO(), key, rudder)).To(Succeed())
+		if rudder.Spec.Position == "left" {
+			rudder.Spec.Position = "right"
+		} else {
+			rudder.Spec.Position = "left"
+		}
+		return c.Update(context.TODO(), rudder, opts...)
+	}
+
+	It("Fills in the defaults for a nil spec", func() {
+		nilSpec := &unstructured.Unstructured{}
+		nilSpec.SetGroupVersionKind(GroupVersion.WithKind("Rudder"))
+		nilSpec.SetName(key.Name)
+		nilSpec.SetNamespace(key.Namespace)
+		nilSpec.Object["spec"] = nil
+		Expect(k8sClient.Create(context.TODO(), nilSpec)).To(Succeed())
+
+		Expect(k8sClient.Get(context.TODO(), key, rudder)).To(Succeed())
+		Expect(rudder.Spec.Position).To(Equal("neutral"))
+		Expect(rudder.Spec.MinDeflection).To(HaveValue(Equal(int32(-25))))
+		Expect(rudder.Spec.MaxDeflection).To(HaveValue(Equal(int32(25))))
+		Expect(rudder.Spec.SlewRate).To(Equal(int32(60)))
+	})
+
+	It("Keeps a travel limit of zero", func() {
+		zero := int32(0)
+		rudder.Spec.MaxDeflection = &zero
+		Expect(k8sClient.Create(context.TODO(), rudder)).To(Succeed())
+
+		Expect(k8sClient.Get(context.TODO(), key, rudder)).To(Succeed())
+		Expect(rudder.Spec.MinDeflection).To(HaveValue(Equal(int32(-25))))
+		Expect(rudder.Spec.MaxDeflection).To(HaveValue(BeZero()))
+	})
+
+	It("Lets a rudder without a linkage be moved by hand", func() {
+		Expect(k8sClient.Create(context.TODO(), rudder)).To(Succeed())
+		Expect(moveRudder(pilotClient)).To(Succeed())
+	})
+
+	It("Rejects moving a rudder driven by pedals", func() {
+		pedals := &Pedals{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      key.Name,
+				Namespace: key.Namespace,
+			},
+		}
+		Expect(k8sClient.Create(context.TODO(), pedals)).To(Succeed())
+		Expect(k8sClient.Create(context.TODO(), rudder)).To(Succeed())
+
+		By("moving the rudder by hand")
+		// The webhook may not have seen the pedals yet.
+		Eventually(func() error {
+			return moveRudder(pilotClient)
+		}).ShouldNot(Succeed())
+
+		By("moving the rudder by hand, claiming to be the linkage")
+		Expect(moveRudder(pilotClient, client.FieldOwner(LinkageFieldManager))).NotTo(Succeed())
+
+		By("moving the rudder as the linkage")
+		Expect(moveRudder(k8sClient, client.FieldOwner(LinkageFieldManager))).To(Succeed())
+
+		By("changing the rudder's rigging")
+		Expect(pilotClient.Get(context.TODO(), key, rudder)).To(Succeed())
+		rudder.Spec.SlewRate = 30
+		Expect(pilotClient.Update(context.TODO(), rudder)).To(Succeed())
+
+		Expect(k8sClient.Delete(context.TODO(), pedals)).To(Succeed())
+	})
+
+	It("Rejects moving a rudder driven by a Linkage", func() {
+		linkage := &Linkage{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      key.Name,
+				Namespace: key.Namespace,
+			},
+			Spec: LinkageSpec{
+				SourceRef: FieldReference{Kind: "Yoke", Name: key.Name, Field: "status.rollLinkageTravel"},
+				TargetRef: FieldReference{Kind: "Rudder", Name: key.Name + "-rudder", Field: "spec.deflection"},
+			},
+		}
+		Expect(k8sClient.Create(context.TODO(), linkage)).To(Succeed())
+		rudder.Name = linkage.Spec.TargetRef.Name
+		key.Name = rudder.Name
+		Expect(k8sClient.Create(context.TODO(), rudder)).To(Succeed())
+
+		Eventually(func() error {
+			return moveRudder(pilotClient)
+		}).ShouldNot(Succeed())
+
+		Expect(k8sClient.Delete(context.TODO(), linkage)).To(Succeed())
+	})
+})
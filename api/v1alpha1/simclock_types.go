@@ -0,0 +1,93 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// SimClockName is the name of the SimClock that the simulation in a
+// namespace follows.  Namespaces without one run in real time.
+const SimClockName = "default"
+
+// SimClockSpec defines the desired state of SimClock
+type SimClockSpec struct {
+	// Paused freezes simulation time.  Moving parts stop where they are
+	// until the clock is resumed or stepped.
+	Paused bool `json:"paused,omitempty"`
+
+	// Rate is how many times faster than real time the simulation runs.
+	// +kubebuilder:validation:Enum=1;2;4
+	// +kubebuilder:default:=1
+	Rate int32 `json:"rate,omitempty"`
+
+	// Steps counts single steps. While the clock is paused, each increment
+	// advances simulation time by StepSize. Increments made while the
+	// clock is running are acknowledged but have no effect.
+	// +kubebuilder:validation:Minimum:=0
+	Steps int32 `json:"steps,omitempty"`
+
+	// StepSize is how far a single step advances simulation time.
+	// +kubebuilder:default:="1s"
+	StepSize *metav1.Duration `json:"stepSize,omitempty"`
+}
+
+// SimClockStatus defines the observed state of SimClock
+type SimClockStatus struct {
+	// Time is the simulation time at the moment given by Anchor.
+	Time *metav1.MicroTime `json:"time,omitempty"`
+
+	// Anchor is the wall-clock time at which Time was read. Simulation
+	// time advances from there at Rate.
+	Anchor *metav1.MicroTime `json:"anchor,omitempty"`
+
+	// Rate is how fast simulation time is advancing. It is zero while the
+	// clock is paused.
+	Rate int32 `json:"rate,omitempty"`
+
+	// Steps is the number of single steps that have been taken.
+	Steps int32 `json:"steps,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="PAUSED",type="boolean",JSONPath=".spec.paused",description="Simulation time is frozen"
+//+kubebuilder:printcolumn:name="RATE",type="integer",JSONPath=".status.rate",description="Speed of simulation time relative to real time"
+//+kubebuilder:printcolumn:name="STEPS",type="integer",JSONPath=".status.steps",description="Single steps taken"
+//+kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+
+// SimClock is the Schema for the simclocks API
+type SimClock struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   SimClockSpec   `json:"spec"`
+	Status SimClockStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// SimClockList contains a list of SimClock
+type SimClockList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []SimClock `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&SimClock{}, &SimClockList{})
+}
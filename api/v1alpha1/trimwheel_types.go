@@ -0,0 +1,65 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// TrimWheelSpec defines the desired state of TrimWheel
+type TrimWheelSpec struct {
+	// Setting is where the trim wheel is turned to, as a percentage of its
+	// full range. Positive values trim the nose up, negative values trim
+	// it down.
+	// +kubebuilder:validation:Minimum:=-100
+	// +kubebuilder:validation:Maximum:=100
+	Setting int32 `json:"setting,omitempty"`
+}
+
+// TrimWheelStatus defines the observed state of TrimWheel
+type TrimWheelStatus struct {
+	// TrimTravel indicates how far the trim linkage has travelled, as a
+	// percentage of its full range.
+	TrimTravel int32 `json:"trimTravel,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="SETTING",type="integer",JSONPath=".spec.setting",description="Percentage of trim wheel range"
+//+kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+
+// TrimWheel is the Schema for the trimwheels API
+type TrimWheel struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   TrimWheelSpec   `json:"spec"`
+	Status TrimWheelStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// TrimWheelList contains a list of TrimWheel
+type TrimWheelList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []TrimWheel `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&TrimWheel{}, &TrimWheelList{})
+}
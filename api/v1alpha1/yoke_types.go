@@ -0,0 +1,76 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// YokeSpec defines the desired state of Yoke
+type YokeSpec struct {
+	// Roll is how far the yoke is turned, as a percentage of full travel.
+	// Negative values roll to the left, positive values roll to the right.
+	// +kubebuilder:validation:Minimum:=-100
+	// +kubebuilder:validation:Maximum:=100
+	Roll int32 `json:"roll,omitempty"`
+
+	// Pitch is how far the yoke is pulled back, as a percentage of full
+	// travel. Negative values push the yoke forward.
+	// +kubebuilder:validation:Minimum:=-100
+	// +kubebuilder:validation:Maximum:=100
+	Pitch int32 `json:"pitch,omitempty"`
+}
+
+// YokeStatus defines the observed state of Yoke
+type YokeStatus struct {
+	// RollLinkageTravel indicates how far the roll linkage has travelled,
+	// as a percentage of full travel. Negative values are to the left.
+	RollLinkageTravel int32 `json:"rollLinkageTravel,omitempty"`
+
+	// PitchLinkageTravel indicates how far the pitch linkage has
+	// travelled, as a percentage of full travel. Negative values are
+	// forward.
+	PitchLinkageTravel int32 `json:"pitchLinkageTravel,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="ROLL",type="integer",JSONPath=".spec.roll",description="Percentage of yoke roll travel"
+//+kubebuilder:printcolumn:name="PITCH",type="integer",JSONPath=".spec.pitch",description="Percentage of yoke pitch travel"
+//+kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+
+// Yoke is the Schema for the yokes API
+type Yoke struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   YokeSpec   `json:"spec"`
+	Status YokeStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// YokeList contains a list of Yoke
+type YokeList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Yoke `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Yoke{}, &YokeList{})
+}
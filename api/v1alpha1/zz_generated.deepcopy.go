@@ -22,16 +22,213 @@ limitations under the License.
 package v1alpha1
 
 import (
-	runtime "k8s.io/apimachinery/pkg/runtime"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Airplane) DeepCopyInto(out *Airplane) {
+func (in *AerodynamicsParameters) DeepCopyInto(out *AerodynamicsParameters) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AerodynamicsParameters.
+func (in *AerodynamicsParameters) DeepCopy() *AerodynamicsParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(AerodynamicsParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Aileron) DeepCopyInto(out *Aileron) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	out.Spec = in.Spec
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Aileron.
+func (in *Aileron) DeepCopy() *Aileron {
+	if in == nil {
+		return nil
+	}
+	out := new(Aileron)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Aileron) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AileronList) DeepCopyInto(out *AileronList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Aileron, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AileronList.
+func (in *AileronList) DeepCopy() *AileronList {
+	if in == nil {
+		return nil
+	}
+	out := new(AileronList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AileronList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AileronSpec) DeepCopyInto(out *AileronSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AileronSpec.
+func (in *AileronSpec) DeepCopy() *AileronSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AileronSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AileronStatus) DeepCopyInto(out *AileronStatus) {
+	*out = *in
+	if in.LastMoved != nil {
+		in, out := &in.LastMoved, &out.LastMoved
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AileronStatus.
+func (in *AileronStatus) DeepCopy() *AileronStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(AileronStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AircraftType) DeepCopyInto(out *AircraftType) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AircraftType.
+func (in *AircraftType) DeepCopy() *AircraftType {
+	if in == nil {
+		return nil
+	}
+	out := new(AircraftType)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AircraftType) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AircraftTypeList) DeepCopyInto(out *AircraftTypeList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]AircraftType, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AircraftTypeList.
+func (in *AircraftTypeList) DeepCopy() *AircraftTypeList {
+	if in == nil {
+		return nil
+	}
+	out := new(AircraftTypeList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AircraftTypeList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AircraftTypeSpec) DeepCopyInto(out *AircraftTypeSpec) {
+	*out = *in
+	in.Rudder.DeepCopyInto(&out.Rudder)
+	in.Aileron.DeepCopyInto(&out.Aileron)
+	in.Elevator.DeepCopyInto(&out.Elevator)
+	if in.Flaps != nil {
+		in, out := &in.Flaps, &out.Flaps
+		*out = new(FlapsParameters)
+		**out = **in
+	}
+	if in.LandingGear != nil {
+		in, out := &in.LandingGear, &out.LandingGear
+		*out = new(LandingGearParameters)
+		(*in).DeepCopyInto(*out)
+	}
+	out.Aerodynamics = in.Aerodynamics
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AircraftTypeSpec.
+func (in *AircraftTypeSpec) DeepCopy() *AircraftTypeSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AircraftTypeSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Airplane) DeepCopyInto(out *Airplane) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Airplane.
@@ -87,6 +284,11 @@ func (in *AirplaneList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AirplaneSpec) DeepCopyInto(out *AirplaneSpec) {
 	*out = *in
+	if in.TypeRef != nil {
+		in, out := &in.TypeRef, &out.TypeRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AirplaneSpec.
@@ -102,8 +304,41 @@ func (in *AirplaneSpec) DeepCopy() *AirplaneSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AirplaneStatus) DeepCopyInto(out *AirplaneStatus) {
 	*out = *in
+	if in.PreviousRegistrations != nil {
+		in, out := &in.PreviousRegistrations, &out.PreviousRegistrations
+		*out = make([]PreviousRegistration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.WeightOnWheels != nil {
+		in, out := &in.WeightOnWheels, &out.WeightOnWheels
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Flight != nil {
+		in, out := &in.Flight, &out.Flight
+		*out = new(FlightStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	out.Gear = in.Gear
 	out.Rudder = in.Rudder
 	out.Pedals = in.Pedals
+	out.Yoke = in.Yoke
+	out.Aileron = in.Aileron
+	out.Elevator = in.Elevator
+	out.TrimWheel = in.TrimWheel
+	out.FlapLever = in.FlapLever
+	out.Flaps = in.Flaps
+	out.GearLever = in.GearLever
+	out.LandingGear = in.LandingGear
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AirplaneStatus.
@@ -117,26 +352,26 @@ func (in *AirplaneStatus) DeepCopy() *AirplaneStatus {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Pedals) DeepCopyInto(out *Pedals) {
+func (in *Elevator) DeepCopyInto(out *Elevator) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	out.Spec = in.Spec
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Pedals.
-func (in *Pedals) DeepCopy() *Pedals {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Elevator.
+func (in *Elevator) DeepCopy() *Elevator {
 	if in == nil {
 		return nil
 	}
-	out := new(Pedals)
+	out := new(Elevator)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *Pedals) DeepCopyObject() runtime.Object {
+func (in *Elevator) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -144,31 +379,31 @@ func (in *Pedals) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PedalsList) DeepCopyInto(out *PedalsList) {
+func (in *ElevatorList) DeepCopyInto(out *ElevatorList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]Pedals, len(*in))
+		*out = make([]Elevator, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PedalsList.
-func (in *PedalsList) DeepCopy() *PedalsList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElevatorList.
+func (in *ElevatorList) DeepCopy() *ElevatorList {
 	if in == nil {
 		return nil
 	}
-	out := new(PedalsList)
+	out := new(ElevatorList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *PedalsList) DeepCopyObject() runtime.Object {
+func (in *ElevatorList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -176,37 +411,72 @@ func (in *PedalsList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PedalsSpec) DeepCopyInto(out *PedalsSpec) {
+func (in *ElevatorParameters) DeepCopyInto(out *ElevatorParameters) {
 	*out = *in
+	in.SurfaceParameters.DeepCopyInto(&out.SurfaceParameters)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PedalsSpec.
-func (in *PedalsSpec) DeepCopy() *PedalsSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElevatorParameters.
+func (in *ElevatorParameters) DeepCopy() *ElevatorParameters {
 	if in == nil {
 		return nil
 	}
-	out := new(PedalsSpec)
+	out := new(ElevatorParameters)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PedalsStatus) DeepCopyInto(out *PedalsStatus) {
+func (in *ElevatorSpec) DeepCopyInto(out *ElevatorSpec) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PedalsStatus.
-func (in *PedalsStatus) DeepCopy() *PedalsStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElevatorSpec.
+func (in *ElevatorSpec) DeepCopy() *ElevatorSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(PedalsStatus)
+	out := new(ElevatorSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Rudder) DeepCopyInto(out *Rudder) {
+func (in *ElevatorStatus) DeepCopyInto(out *ElevatorStatus) {
+	*out = *in
+	if in.LastMoved != nil {
+		in, out := &in.LastMoved, &out.LastMoved
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElevatorStatus.
+func (in *ElevatorStatus) DeepCopy() *ElevatorStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ElevatorStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FieldReference) DeepCopyInto(out *FieldReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FieldReference.
+func (in *FieldReference) DeepCopy() *FieldReference {
+	if in == nil {
+		return nil
+	}
+	out := new(FieldReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FlapLever) DeepCopyInto(out *FlapLever) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -214,18 +484,18 @@ func (in *Rudder) DeepCopyInto(out *Rudder) {
 	out.Status = in.Status
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Rudder.
-func (in *Rudder) DeepCopy() *Rudder {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FlapLever.
+func (in *FlapLever) DeepCopy() *FlapLever {
 	if in == nil {
 		return nil
 	}
-	out := new(Rudder)
+	out := new(FlapLever)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *Rudder) DeepCopyObject() runtime.Object {
+func (in *FlapLever) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -233,31 +503,31 @@ func (in *Rudder) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RudderList) DeepCopyInto(out *RudderList) {
+func (in *FlapLeverList) DeepCopyInto(out *FlapLeverList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]Rudder, len(*in))
+		*out = make([]FlapLever, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RudderList.
-func (in *RudderList) DeepCopy() *RudderList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FlapLeverList.
+func (in *FlapLeverList) DeepCopy() *FlapLeverList {
 	if in == nil {
 		return nil
 	}
-	out := new(RudderList)
+	out := new(FlapLeverList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *RudderList) DeepCopyObject() runtime.Object {
+func (in *FlapLeverList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -265,31 +535,1253 @@ func (in *RudderList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RudderSpec) DeepCopyInto(out *RudderSpec) {
+func (in *FlapLeverSpec) DeepCopyInto(out *FlapLeverSpec) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RudderSpec.
-func (in *RudderSpec) DeepCopy() *RudderSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FlapLeverSpec.
+func (in *FlapLeverSpec) DeepCopy() *FlapLeverSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(RudderSpec)
+	out := new(FlapLeverSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RudderStatus) DeepCopyInto(out *RudderStatus) {
+func (in *FlapLeverStatus) DeepCopyInto(out *FlapLeverStatus) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RudderStatus.
-func (in *RudderStatus) DeepCopy() *RudderStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FlapLeverStatus.
+func (in *FlapLeverStatus) DeepCopy() *FlapLeverStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(RudderStatus)
+	out := new(FlapLeverStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Flaps) DeepCopyInto(out *Flaps) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Flaps.
+func (in *Flaps) DeepCopy() *Flaps {
+	if in == nil {
+		return nil
+	}
+	out := new(Flaps)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Flaps) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FlapsList) DeepCopyInto(out *FlapsList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Flaps, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FlapsList.
+func (in *FlapsList) DeepCopy() *FlapsList {
+	if in == nil {
+		return nil
+	}
+	out := new(FlapsList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *FlapsList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FlapsParameters) DeepCopyInto(out *FlapsParameters) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FlapsParameters.
+func (in *FlapsParameters) DeepCopy() *FlapsParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(FlapsParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FlapsSpec) DeepCopyInto(out *FlapsSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FlapsSpec.
+func (in *FlapsSpec) DeepCopy() *FlapsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(FlapsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FlapsStatus) DeepCopyInto(out *FlapsStatus) {
+	*out = *in
+	if in.LastMoved != nil {
+		in, out := &in.LastMoved, &out.LastMoved
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FlapsStatus.
+func (in *FlapsStatus) DeepCopy() *FlapsStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(FlapsStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FlightStatus) DeepCopyInto(out *FlightStatus) {
+	*out = *in
+	if in.LastStepped != nil {
+		in, out := &in.LastStepped, &out.LastStepped
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FlightStatus.
+func (in *FlightStatus) DeepCopy() *FlightStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(FlightStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GearLever) DeepCopyInto(out *GearLever) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	out.Status = in.Status
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GearLever.
+func (in *GearLever) DeepCopy() *GearLever {
+	if in == nil {
+		return nil
+	}
+	out := new(GearLever)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GearLever) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GearLeverList) DeepCopyInto(out *GearLeverList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]GearLever, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GearLeverList.
+func (in *GearLeverList) DeepCopy() *GearLeverList {
+	if in == nil {
+		return nil
+	}
+	out := new(GearLeverList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GearLeverList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GearLeverSpec) DeepCopyInto(out *GearLeverSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GearLeverSpec.
+func (in *GearLeverSpec) DeepCopy() *GearLeverSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GearLeverSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GearLeverStatus) DeepCopyInto(out *GearLeverStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GearLeverStatus.
+func (in *GearLeverStatus) DeepCopy() *GearLeverStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(GearLeverStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GearSummary) DeepCopyInto(out *GearSummary) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GearSummary.
+func (in *GearSummary) DeepCopy() *GearSummary {
+	if in == nil {
+		return nil
+	}
+	out := new(GearSummary)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LandingGear) DeepCopyInto(out *LandingGear) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LandingGear.
+func (in *LandingGear) DeepCopy() *LandingGear {
+	if in == nil {
+		return nil
+	}
+	out := new(LandingGear)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *LandingGear) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LandingGearList) DeepCopyInto(out *LandingGearList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]LandingGear, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LandingGearList.
+func (in *LandingGearList) DeepCopy() *LandingGearList {
+	if in == nil {
+		return nil
+	}
+	out := new(LandingGearList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *LandingGearList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LandingGearParameters) DeepCopyInto(out *LandingGearParameters) {
+	*out = *in
+	if in.ExtensionTime != nil {
+		in, out := &in.ExtensionTime, &out.ExtensionTime
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.RetractionTime != nil {
+		in, out := &in.RetractionTime, &out.RetractionTime
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LandingGearParameters.
+func (in *LandingGearParameters) DeepCopy() *LandingGearParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(LandingGearParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LandingGearSpec) DeepCopyInto(out *LandingGearSpec) {
+	*out = *in
+	if in.ExtensionTime != nil {
+		in, out := &in.ExtensionTime, &out.ExtensionTime
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.RetractionTime != nil {
+		in, out := &in.RetractionTime, &out.RetractionTime
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LandingGearSpec.
+func (in *LandingGearSpec) DeepCopy() *LandingGearSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(LandingGearSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LandingGearStatus) DeepCopyInto(out *LandingGearStatus) {
+	*out = *in
+	if in.TransitStarted != nil {
+		in, out := &in.TransitStarted, &out.TransitStarted
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LandingGearStatus.
+func (in *LandingGearStatus) DeepCopy() *LandingGearStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(LandingGearStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Linkage) DeepCopyInto(out *Linkage) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Linkage.
+func (in *Linkage) DeepCopy() *Linkage {
+	if in == nil {
+		return nil
+	}
+	out := new(Linkage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Linkage) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LinkageList) DeepCopyInto(out *LinkageList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Linkage, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LinkageList.
+func (in *LinkageList) DeepCopy() *LinkageList {
+	if in == nil {
+		return nil
+	}
+	out := new(LinkageList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *LinkageList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LinkageSpec) DeepCopyInto(out *LinkageSpec) {
+	*out = *in
+	out.SourceRef = in.SourceRef
+	out.TargetRef = in.TargetRef
+	if in.Gain != nil {
+		in, out := &in.Gain, &out.Gain
+		*out = new(float64)
+		**out = **in
+	}
+	if in.Min != nil {
+		in, out := &in.Min, &out.Min
+		*out = new(float64)
+		**out = **in
+	}
+	if in.Max != nil {
+		in, out := &in.Max, &out.Max
+		*out = new(float64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LinkageSpec.
+func (in *LinkageSpec) DeepCopy() *LinkageSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(LinkageSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LinkageStatus) DeepCopyInto(out *LinkageStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LinkageStatus.
+func (in *LinkageStatus) DeepCopy() *LinkageStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(LinkageStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Pedals) DeepCopyInto(out *Pedals) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Pedals.
+func (in *Pedals) DeepCopy() *Pedals {
+	if in == nil {
+		return nil
+	}
+	out := new(Pedals)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Pedals) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PedalsList) DeepCopyInto(out *PedalsList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Pedals, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PedalsList.
+func (in *PedalsList) DeepCopy() *PedalsList {
+	if in == nil {
+		return nil
+	}
+	out := new(PedalsList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PedalsList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PedalsSpec) DeepCopyInto(out *PedalsSpec) {
+	*out = *in
+	if in.Travel != nil {
+		in, out := &in.Travel, &out.Travel
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PedalsSpec.
+func (in *PedalsSpec) DeepCopy() *PedalsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PedalsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PedalsStatus) DeepCopyInto(out *PedalsStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PedalsStatus.
+func (in *PedalsStatus) DeepCopy() *PedalsStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PedalsStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PreviousRegistration) DeepCopyInto(out *PreviousRegistration) {
+	*out = *in
+	in.Until.DeepCopyInto(&out.Until)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PreviousRegistration.
+func (in *PreviousRegistration) DeepCopy() *PreviousRegistration {
+	if in == nil {
+		return nil
+	}
+	out := new(PreviousRegistration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Registration) DeepCopyInto(out *Registration) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Registration.
+func (in *Registration) DeepCopy() *Registration {
+	if in == nil {
+		return nil
+	}
+	out := new(Registration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Registration) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RegistrationList) DeepCopyInto(out *RegistrationList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Registration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RegistrationList.
+func (in *RegistrationList) DeepCopy() *RegistrationList {
+	if in == nil {
+		return nil
+	}
+	out := new(RegistrationList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RegistrationList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RegistrationSpec) DeepCopyInto(out *RegistrationSpec) {
+	*out = *in
+	out.AirplaneRef = in.AirplaneRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RegistrationSpec.
+func (in *RegistrationSpec) DeepCopy() *RegistrationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RegistrationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Replay) DeepCopyInto(out *Replay) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Replay.
+func (in *Replay) DeepCopy() *Replay {
+	if in == nil {
+		return nil
+	}
+	out := new(Replay)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Replay) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReplayDivergence) DeepCopyInto(out *ReplayDivergence) {
+	*out = *in
+	out.Offset = in.Offset
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplayDivergence.
+func (in *ReplayDivergence) DeepCopy() *ReplayDivergence {
+	if in == nil {
+		return nil
+	}
+	out := new(ReplayDivergence)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReplayList) DeepCopyInto(out *ReplayList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Replay, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplayList.
+func (in *ReplayList) DeepCopy() *ReplayList {
+	if in == nil {
+		return nil
+	}
+	out := new(ReplayList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ReplayList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReplaySpec) DeepCopyInto(out *ReplaySpec) {
+	*out = *in
+	out.AirplaneRef = in.AirplaneRef
+	out.Timeline = in.Timeline
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplaySpec.
+func (in *ReplaySpec) DeepCopy() *ReplaySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ReplaySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReplayStatus) DeepCopyInto(out *ReplayStatus) {
+	*out = *in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Divergences != nil {
+		in, out := &in.Divergences, &out.Divergences
+		*out = make([]ReplayDivergence, len(*in))
+		copy(*out, *in)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplayStatus.
+func (in *ReplayStatus) DeepCopy() *ReplayStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ReplayStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Rudder) DeepCopyInto(out *Rudder) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Rudder.
+func (in *Rudder) DeepCopy() *Rudder {
+	if in == nil {
+		return nil
+	}
+	out := new(Rudder)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Rudder) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RudderList) DeepCopyInto(out *RudderList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Rudder, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RudderList.
+func (in *RudderList) DeepCopy() *RudderList {
+	if in == nil {
+		return nil
+	}
+	out := new(RudderList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RudderList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RudderSpec) DeepCopyInto(out *RudderSpec) {
+	*out = *in
+	if in.Deflection != nil {
+		in, out := &in.Deflection, &out.Deflection
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MinDeflection != nil {
+		in, out := &in.MinDeflection, &out.MinDeflection
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxDeflection != nil {
+		in, out := &in.MaxDeflection, &out.MaxDeflection
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RudderSpec.
+func (in *RudderSpec) DeepCopy() *RudderSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RudderSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RudderStatus) DeepCopyInto(out *RudderStatus) {
+	*out = *in
+	if in.LastMoved != nil {
+		in, out := &in.LastMoved, &out.LastMoved
+		*out = (*in).DeepCopy()
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RudderStatus.
+func (in *RudderStatus) DeepCopy() *RudderStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RudderStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SimClock) DeepCopyInto(out *SimClock) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SimClock.
+func (in *SimClock) DeepCopy() *SimClock {
+	if in == nil {
+		return nil
+	}
+	out := new(SimClock)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SimClock) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SimClockList) DeepCopyInto(out *SimClockList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]SimClock, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SimClockList.
+func (in *SimClockList) DeepCopy() *SimClockList {
+	if in == nil {
+		return nil
+	}
+	out := new(SimClockList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SimClockList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SimClockSpec) DeepCopyInto(out *SimClockSpec) {
+	*out = *in
+	if in.StepSize != nil {
+		in, out := &in.StepSize, &out.StepSize
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SimClockSpec.
+func (in *SimClockSpec) DeepCopy() *SimClockSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SimClockSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SimClockStatus) DeepCopyInto(out *SimClockStatus) {
+	*out = *in
+	if in.Time != nil {
+		in, out := &in.Time, &out.Time
+		*out = (*in).DeepCopy()
+	}
+	if in.Anchor != nil {
+		in, out := &in.Anchor, &out.Anchor
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SimClockStatus.
+func (in *SimClockStatus) DeepCopy() *SimClockStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SimClockStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SurfaceParameters) DeepCopyInto(out *SurfaceParameters) {
+	*out = *in
+	if in.MinDeflection != nil {
+		in, out := &in.MinDeflection, &out.MinDeflection
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxDeflection != nil {
+		in, out := &in.MaxDeflection, &out.MaxDeflection
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SurfaceParameters.
+func (in *SurfaceParameters) DeepCopy() *SurfaceParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(SurfaceParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TimelineSource) DeepCopyInto(out *TimelineSource) {
+	*out = *in
+	out.ConfigMapRef = in.ConfigMapRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TimelineSource.
+func (in *TimelineSource) DeepCopy() *TimelineSource {
+	if in == nil {
+		return nil
+	}
+	out := new(TimelineSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TrimWheel) DeepCopyInto(out *TrimWheel) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	out.Status = in.Status
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TrimWheel.
+func (in *TrimWheel) DeepCopy() *TrimWheel {
+	if in == nil {
+		return nil
+	}
+	out := new(TrimWheel)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TrimWheel) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TrimWheelList) DeepCopyInto(out *TrimWheelList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]TrimWheel, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TrimWheelList.
+func (in *TrimWheelList) DeepCopy() *TrimWheelList {
+	if in == nil {
+		return nil
+	}
+	out := new(TrimWheelList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TrimWheelList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TrimWheelSpec) DeepCopyInto(out *TrimWheelSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TrimWheelSpec.
+func (in *TrimWheelSpec) DeepCopy() *TrimWheelSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TrimWheelSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TrimWheelStatus) DeepCopyInto(out *TrimWheelStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TrimWheelStatus.
+func (in *TrimWheelStatus) DeepCopy() *TrimWheelStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TrimWheelStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Yoke) DeepCopyInto(out *Yoke) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	out.Status = in.Status
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Yoke.
+func (in *Yoke) DeepCopy() *Yoke {
+	if in == nil {
+		return nil
+	}
+	out := new(Yoke)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Yoke) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *YokeList) DeepCopyInto(out *YokeList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Yoke, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new YokeList.
+func (in *YokeList) DeepCopy() *YokeList {
+	if in == nil {
+		return nil
+	}
+	out := new(YokeList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *YokeList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *YokeSpec) DeepCopyInto(out *YokeSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new YokeSpec.
+func (in *YokeSpec) DeepCopy() *YokeSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(YokeSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *YokeStatus) DeepCopyInto(out *YokeStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new YokeStatus.
+func (in *YokeStatus) DeepCopy() *YokeStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(YokeStatus)
 	in.DeepCopyInto(out)
 	return out
 }
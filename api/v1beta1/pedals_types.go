@@ -0,0 +1,83 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// PedalsSpec defines the desired state of Pedals
+type PedalsSpec struct {
+	// Travel is how far the pedals are pushed, as a percentage of full
+	// travel. Negative values press the left pedal, positive values press
+	// the right pedal.
+	// +kubebuilder:validation:Minimum:=-100
+	// +kubebuilder:validation:Maximum:=100
+	Travel int32 `json:"travel,omitempty"`
+}
+
+// PedalsStatus defines the observed state of Pedals
+type PedalsStatus struct {
+	// LinkageTravel indicates how far the pedal linkage has travelled, as
+	// a percentage of full travel. Negative values are to the left.
+	LinkageTravel int32 `json:"linkageTravel,omitempty"`
+
+	// ObservedGeneration is the generation of the spec that the status
+	// reflects.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Conditions describe the state of the pedals. ControlsLinked is true
+	// while the pedal linkage is connected to the rudder, and LinkageBroken
+	// is true while it isn't. Ready is true when the rudder has been set to
+	// follow the pedals.
+	// +patchMergeKey=type
+	// +patchStrategy=merge
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:storageversion
+//+kubebuilder:printcolumn:name="TRAVEL",type="integer",JSONPath=".spec.travel",description="Percentage of pedal travel"
+//+kubebuilder:printcolumn:name="LINKAGE",type="integer",JSONPath=".status.linkageTravel",description="Percentage of pedal linkage travel"
+//+kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].status",description="Ready condition"
+//+kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+
+// Pedals is the Schema for the pedals API
+type Pedals struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   PedalsSpec   `json:"spec"`
+	Status PedalsStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// PedalsList contains a list of Pedals
+type PedalsList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Pedals `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Pedals{}, &PedalsList{})
+}
@@ -0,0 +1,120 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// The travel limits of a rudder that doesn't give its own.
+const (
+	DefaultRudderMinDeflection int32 = -25
+	DefaultRudderMaxDeflection int32 = 25
+)
+
+// RudderSpec defines the desired state of Rudder
+type RudderSpec struct {
+	// Deflection is the desired rudder deflection in degrees. Negative
+	// values are to the left, positive values are to the right.
+	Deflection int32 `json:"deflection,omitempty"`
+
+	// MinDeflection is the travel limit to the left, in degrees. Zero
+	// keeps the rudder from moving left.
+	// +kubebuilder:validation:Maximum:=0
+	// +kubebuilder:default:=-25
+	// +optional
+	MinDeflection *int32 `json:"minDeflection,omitempty"`
+
+	// MaxDeflection is the travel limit to the right, in degrees. Zero
+	// keeps the rudder from moving right.
+	// +kubebuilder:validation:Minimum:=0
+	// +kubebuilder:default:=25
+	// +optional
+	MaxDeflection *int32 `json:"maxDeflection,omitempty"`
+
+	// SlewRate is the speed of the rudder actuator, in degrees per second.
+	// +kubebuilder:validation:Minimum:=1
+	// +kubebuilder:validation:Maximum:=1000
+	// +kubebuilder:default:=60
+	SlewRate int32 `json:"slewRate,omitempty"`
+}
+
+// Limits returns the travel limits, in degrees.  A limit that isn't given
+// has its default.
+func (s *RudderSpec) Limits() (int32, int32) {
+	minDeflection, maxDeflection := DefaultRudderMinDeflection, DefaultRudderMaxDeflection
+	if s.MinDeflection != nil {
+		minDeflection = *s.MinDeflection
+	}
+	if s.MaxDeflection != nil {
+		maxDeflection = *s.MaxDeflection
+	}
+	return minDeflection, maxDeflection
+}
+
+// RudderStatus defines the observed state of Rudder
+type RudderStatus struct {
+	// Deflection is the current rudder deflection in degrees.
+	Deflection int32 `json:"deflection,omitempty"`
+
+	// LastMoved is the time the actuator last advanced the rudder. It is
+	// cleared when the rudder reaches the desired deflection.
+	LastMoved *metav1.MicroTime `json:"lastMoved,omitempty"`
+
+	// ObservedGeneration is the generation of the spec that the status
+	// reflects.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Conditions describe the state of the rudder. Ready is true once the
+	// rudder has reached the desired deflection.
+	// +patchMergeKey=type
+	// +patchStrategy=merge
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:storageversion
+//+kubebuilder:printcolumn:name="DESIRED",type="integer",JSONPath=".spec.deflection",description="Desired deflection of rudder in degrees"
+//+kubebuilder:printcolumn:name="DEFLECTION",type="integer",JSONPath=".status.deflection",description="Current deflection of rudder in degrees"
+//+kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].status",description="Ready condition"
+//+kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+
+// Rudder is the Schema for the rudders API
+type Rudder struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   RudderSpec   `json:"spec"`
+	Status RudderStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// RudderList contains a list of Rudder
+type RudderList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Rudder `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Rudder{}, &RudderList{})
+}
@@ -0,0 +1,413 @@
+//go:build !ignore_autogenerated
+// +build !ignore_autogenerated
+
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by controller-gen. DO NOT EDIT.
+
+package v1beta1
+
+import (
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+)
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Airplane) DeepCopyInto(out *Airplane) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Airplane.
+func (in *Airplane) DeepCopy() *Airplane {
+	if in == nil {
+		return nil
+	}
+	out := new(Airplane)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Airplane) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AirplaneList) DeepCopyInto(out *AirplaneList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Airplane, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AirplaneList.
+func (in *AirplaneList) DeepCopy() *AirplaneList {
+	if in == nil {
+		return nil
+	}
+	out := new(AirplaneList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AirplaneList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AirplaneSpec) DeepCopyInto(out *AirplaneSpec) {
+	*out = *in
+	if in.TypeRef != nil {
+		in, out := &in.TypeRef, &out.TypeRef
+		*out = new(v1.LocalObjectReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AirplaneSpec.
+func (in *AirplaneSpec) DeepCopy() *AirplaneSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AirplaneSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AirplaneStatus) DeepCopyInto(out *AirplaneStatus) {
+	*out = *in
+	if in.PreviousRegistrations != nil {
+		in, out := &in.PreviousRegistrations, &out.PreviousRegistrations
+		*out = make([]PreviousRegistration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.WeightOnWheels != nil {
+		in, out := &in.WeightOnWheels, &out.WeightOnWheels
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Flight != nil {
+		in, out := &in.Flight, &out.Flight
+		*out = new(FlightStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	out.Gear = in.Gear
+	out.Rudder = in.Rudder
+	out.Pedals = in.Pedals
+	out.Yoke = in.Yoke
+	out.Aileron = in.Aileron
+	out.Elevator = in.Elevator
+	out.TrimWheel = in.TrimWheel
+	out.FlapLever = in.FlapLever
+	out.Flaps = in.Flaps
+	out.GearLever = in.GearLever
+	out.LandingGear = in.LandingGear
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AirplaneStatus.
+func (in *AirplaneStatus) DeepCopy() *AirplaneStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(AirplaneStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FlightStatus) DeepCopyInto(out *FlightStatus) {
+	*out = *in
+	if in.LastStepped != nil {
+		in, out := &in.LastStepped, &out.LastStepped
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FlightStatus.
+func (in *FlightStatus) DeepCopy() *FlightStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(FlightStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GearSummary) DeepCopyInto(out *GearSummary) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GearSummary.
+func (in *GearSummary) DeepCopy() *GearSummary {
+	if in == nil {
+		return nil
+	}
+	out := new(GearSummary)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Pedals) DeepCopyInto(out *Pedals) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Pedals.
+func (in *Pedals) DeepCopy() *Pedals {
+	if in == nil {
+		return nil
+	}
+	out := new(Pedals)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Pedals) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PedalsList) DeepCopyInto(out *PedalsList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Pedals, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PedalsList.
+func (in *PedalsList) DeepCopy() *PedalsList {
+	if in == nil {
+		return nil
+	}
+	out := new(PedalsList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PedalsList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PedalsSpec) DeepCopyInto(out *PedalsSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PedalsSpec.
+func (in *PedalsSpec) DeepCopy() *PedalsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PedalsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PedalsStatus) DeepCopyInto(out *PedalsStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PedalsStatus.
+func (in *PedalsStatus) DeepCopy() *PedalsStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PedalsStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PreviousRegistration) DeepCopyInto(out *PreviousRegistration) {
+	*out = *in
+	in.Until.DeepCopyInto(&out.Until)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PreviousRegistration.
+func (in *PreviousRegistration) DeepCopy() *PreviousRegistration {
+	if in == nil {
+		return nil
+	}
+	out := new(PreviousRegistration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Rudder) DeepCopyInto(out *Rudder) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Rudder.
+func (in *Rudder) DeepCopy() *Rudder {
+	if in == nil {
+		return nil
+	}
+	out := new(Rudder)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Rudder) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RudderList) DeepCopyInto(out *RudderList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Rudder, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RudderList.
+func (in *RudderList) DeepCopy() *RudderList {
+	if in == nil {
+		return nil
+	}
+	out := new(RudderList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RudderList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RudderSpec) DeepCopyInto(out *RudderSpec) {
+	*out = *in
+	if in.MinDeflection != nil {
+		in, out := &in.MinDeflection, &out.MinDeflection
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxDeflection != nil {
+		in, out := &in.MaxDeflection, &out.MaxDeflection
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RudderSpec.
+func (in *RudderSpec) DeepCopy() *RudderSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RudderSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RudderStatus) DeepCopyInto(out *RudderStatus) {
+	*out = *in
+	if in.LastMoved != nil {
+		in, out := &in.LastMoved, &out.LastMoved
+		*out = (*in).DeepCopy()
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RudderStatus.
+func (in *RudderStatus) DeepCopy() *RudderStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RudderStatus)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -0,0 +1,159 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Command airplane-sim is the command line for working with the simulator
+// from outside the cluster.
+//
+//	airplane-sim scenario run [--namespace NAMESPACE] [--junit FILE] SCENARIO.yaml...
+//
+// runs scenarios against the cluster that the kubeconfig points at, and
+// exits non-zero if any of them fails.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+
+	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
+	_ "k8s.io/client-go/plugin/pkg/client/auth"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	playv1alpha1 "github.com/roehrich-hpe/airplane-sim/api/v1alpha1"
+	"github.com/roehrich-hpe/airplane-sim/pkg/scenario"
+)
+
+var scheme = runtime.NewScheme()
+
+func init() {
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(playv1alpha1.AddToScheme(scheme))
+}
+
+const usage = `usage: airplane-sim scenario run [flags] SCENARIO.yaml...
+`
+
+func main() {
+	if len(os.Args) < 3 || os.Args[1] != "scenario" || os.Args[2] != "run" {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+	os.Exit(runScenarios(os.Args[3:], os.Stdout, os.Stderr))
+}
+
+// runScenarios runs the scenario files named on the command line, and
+// returns the exit code.
+func runScenarios(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("scenario run", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	namespace := flags.String("namespace", "default", "The namespace that the scenarios' airplanes live in.")
+	junit := flags.String("junit", "", "Write the results as JUnit XML to this file.")
+	kubeconfig := flags.String("kubeconfig", "", "Path to a kubeconfig. Only required if out-of-cluster.")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if flags.NArg() == 0 {
+		fmt.Fprint(stderr, usage)
+		flags.PrintDefaults()
+		return 2
+	}
+
+	scenarios := []*scenario.Scenario{}
+	for _, path := range flags.Args() {
+		s, err := scenario.Load(path)
+		if err != nil {
+			fmt.Fprintln(stderr, err)
+			return 2
+		}
+		scenarios = append(scenarios, s)
+	}
+
+	if len(*kubeconfig) > 0 {
+		// The config loader only looks at the global flag.
+		if err := flag.Set("kubeconfig", *kubeconfig); err != nil {
+			fmt.Fprintln(stderr, err)
+			return 2
+		}
+	}
+	config, err := ctrl.GetConfig()
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 2
+	}
+	c, err := client.New(config, client.Options{Scheme: scheme})
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 2
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+
+	runner := &scenario.Runner{Client: c, Namespace: *namespace}
+	results := []*scenario.Result{}
+	failed := false
+	for _, s := range scenarios {
+		result := runner.Run(ctx, s)
+		results = append(results, result)
+		report(stdout, result)
+		failed = failed || result.Failed()
+	}
+
+	if len(*junit) > 0 {
+		out, err := os.Create(*junit)
+		if err != nil {
+			fmt.Fprintln(stderr, err)
+			return 2
+		}
+		defer out.Close()
+		if err := scenario.WriteJUnit(out, results); err != nil {
+			fmt.Fprintln(stderr, err)
+			return 2
+		}
+	}
+
+	if failed {
+		return 1
+	}
+	return 0
+}
+
+// report prints how a scenario went.
+func report(w io.Writer, result *scenario.Result) {
+	outcome := "PASS"
+	if result.Failed() {
+		outcome = "FAIL"
+	}
+	fmt.Fprintf(w, "%s %s (%.1fs)\n", outcome, result.Name, result.Duration.Seconds())
+	for _, step := range result.Steps {
+		switch {
+		case step.Skipped:
+			fmt.Fprintf(w, "  skip %s\n", step.Name)
+		case step.Err != nil:
+			fmt.Fprintf(w, "  FAIL %s: %v\n", step.Name, step.Err)
+		default:
+			fmt.Fprintf(w, "  ok   %s\n", step.Name)
+		}
+	}
+}
@@ -0,0 +1,107 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// actuatorTick is the shortest interval between two steps of a moving
+// actuator.  Without it a fast actuator would update its status for every
+// degree of travel.
+const actuatorTick = 100 * time.Millisecond
+
+// slew moves an actuator from current toward target at rate degrees per
+// second.  The lastMoved time marks when the actuator last advanced, and is
+// nil when the actuator is at rest.  It returns the new position, the new
+// lastMoved time, and how long to wait before the next step.  The wait is
+// zero once the actuator has reached its target.
+func slew(current, target, rate int32, lastMoved *metav1.MicroTime, now time.Time) (int32, *metav1.MicroTime, time.Duration) {
+	if current == target {
+		return current, nil, 0
+	}
+	if rate <= 0 {
+		// No rate limit, so we get there immediately.
+		return target, nil, 0
+	}
+	perDegree := time.Second / time.Duration(rate)
+	if perDegree == 0 {
+		// Too fast to time, so we get there immediately.
+		return target, nil, 0
+	}
+
+	wait := perDegree
+	if wait < actuatorTick {
+		wait = actuatorTick
+	}
+
+	if lastMoved == nil {
+		// Starting from rest.  Note the time and take the first step
+		// on the next pass.
+		started := metav1.NewMicroTime(now)
+		return current, &started, wait
+	}
+
+	elapsed := now.Sub(lastMoved.Time)
+	if elapsed < 0 {
+		// The clock went backwards, which happens when a SimClock is
+		// removed.  Start timing again from here.
+		restarted := metav1.NewMicroTime(now)
+		return current, &restarted, wait
+	}
+	steps := int32(elapsed / perDegree)
+	if steps == 0 {
+		return current, lastMoved, perDegree - elapsed
+	}
+
+	distance := target - current
+	if distance < 0 {
+		distance = -distance
+	}
+	if steps >= distance {
+		return target, nil, 0
+	}
+
+	moved := metav1.NewMicroTime(lastMoved.Add(time.Duration(steps) * perDegree))
+	if target < current {
+		return current - steps, &moved, wait
+	}
+	return current + steps, &moved, wait
+}
+
+// clamp limits value to the range [min, max].
+func clamp(value, min, max int32) int32 {
+	if value < min {
+		return min
+	}
+	if value > max {
+		return max
+	}
+	return value
+}
+
+// scaleTravel maps a control travel, as a percentage of full travel, onto a
+// surface's travel limits.  Full travel in either direction gives full
+// deflection on that side.
+func scaleTravel(travel, min, max int32) int32 {
+	if travel < 0 {
+		return travel * -min / 100
+	}
+	return travel * max / 100
+}
@@ -0,0 +1,102 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	playv1alpha1 "github.com/roehrich-hpe/airplane-sim/api/v1alpha1"
+)
+
+// AileronReconciler reconciles a Aileron object
+type AileronReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+}
+
+//+kubebuilder:rbac:groups=play.github.com,resources=ailerons,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=play.github.com,resources=ailerons/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=play.github.com,resources=ailerons/finalizers,verbs=update
+
+// Reconcile moves each aileron toward its desired deflection at the
+// actuator's slew rate.
+//
+// For more details, check Reconcile and its Result here:
+// - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.12.2/pkg/reconcile
+func (r *AileronReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := log.FromContext(ctx).WithName("aileron")
+
+	aileron := &playv1alpha1.Aileron{}
+	if err := r.Get(ctx, req.NamespacedName, aileron); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	clock, err := readClock(ctx, r.Client, req.Namespace)
+	if err != nil {
+		log.Error(err, "Unable to read simulation clock")
+		return ctrl.Result{}, err
+	}
+
+	now := clock.now
+	spec := &aileron.Spec
+	leftTarget := clamp(spec.Left, spec.MinDeflection, spec.MaxDeflection)
+	rightTarget := clamp(spec.Right, spec.MinDeflection, spec.MaxDeflection)
+
+	// Both actuators share the same clock, so whichever of them is still
+	// moving decides when the ailerons last moved.
+	left, leftMoved, leftWait := slew(aileron.Status.Left, leftTarget, spec.SlewRate, aileron.Status.LastMoved, now)
+	right, rightMoved, rightWait := slew(aileron.Status.Right, rightTarget, spec.SlewRate, aileron.Status.LastMoved, now)
+	lastMoved, wait := leftMoved, leftWait
+	if lastMoved == nil {
+		lastMoved, wait = rightMoved, rightWait
+	}
+
+	if aileron.Status.Left != left || aileron.Status.Right != right || !lastMoved.Equal(aileron.Status.LastMoved) {
+		if aileron.Status.Left != left || aileron.Status.Right != right {
+			log.Info("Moving ailerons", "left", left, "right", right)
+		}
+		aileron.Status.Left = left
+		aileron.Status.Right = right
+		aileron.Status.LastMoved = lastMoved
+		if err := r.Status().Update(ctx, aileron); err != nil {
+			if apierrors.IsConflict(err) {
+				log.Info("Conflict while setting position")
+				return ctrl.Result{Requeue: true}, nil
+			}
+			log.Error(err, "Error while setting position")
+			return ctrl.Result{}, err
+		}
+	}
+
+	return ctrl.Result{RequeueAfter: clock.wallTime(wait)}, nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *AileronReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&playv1alpha1.Aileron{}).
+		Watches(&source.Kind{Type: &playv1alpha1.SimClock{}}, handler.EnqueueRequestsFromMapFunc(clockWatcher(mgr.GetClient(), &playv1alpha1.AileronList{}))).
+		Complete(r)
+}
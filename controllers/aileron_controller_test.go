@@ -0,0 +1,98 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	playv1alpha1 "github.com/roehrich-hpe/airplane-sim/api/v1alpha1"
+)
+
+var _ = Describe("Aileron Unit Tests", func() {
+
+	var (
+		key     types.NamespacedName
+		aileron *playv1alpha1.Aileron
+	)
+
+	BeforeEach(func() {
+		key = types.NamespacedName{
+			Name:      "aileron-" + uuid.New().String()[0:8],
+			Namespace: corev1.NamespaceDefault,
+		}
+
+		aileron = &playv1alpha1.Aileron{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      key.Name,
+				Namespace: key.Namespace,
+			},
+		}
+
+		Expect(k8sClient.Create(context.TODO(), aileron)).To(Succeed())
+	})
+
+	AfterEach(func() {
+		Expect(k8sClient.Delete(context.TODO(), aileron)).To(Succeed())
+
+		// Test env limitation: Wait until the cached object is gone.
+		expected := &playv1alpha1.Aileron{}
+		Eventually(func() error {
+			return k8sClient.Get(context.TODO(), key, expected)
+		}).ShouldNot(Succeed())
+	})
+
+	It("Moves each aileron to its own deflection", func() {
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(context.TODO(), key, aileron)).To(Succeed())
+			aileron.Spec.Left = 5
+			aileron.Spec.Right = -8
+			g.Expect(k8sClient.Update(context.TODO(), aileron)).To(Succeed())
+		}).Should(Succeed())
+
+		expected := &playv1alpha1.Aileron{}
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(context.TODO(), key, expected)).To(Succeed())
+			g.Expect(expected.Status.Left).To(Equal(int32(5)))
+			g.Expect(expected.Status.Right).To(Equal(int32(-8)))
+			g.Expect(expected.Status.LastMoved).To(BeNil())
+		}).Should(Succeed())
+	})
+
+	It("Holds the ailerons within their travel limits", func() {
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(context.TODO(), key, aileron)).To(Succeed())
+			aileron.Spec.Left = 90
+			aileron.Spec.Right = -90
+			g.Expect(k8sClient.Update(context.TODO(), aileron)).To(Succeed())
+		}).Should(Succeed())
+
+		expected := &playv1alpha1.Aileron{}
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(context.TODO(), key, expected)).To(Succeed())
+			g.Expect(expected.Status.Left).To(Equal(expected.Spec.MaxDeflection))
+			g.Expect(expected.Status.Right).To(Equal(expected.Spec.MinDeflection))
+		}).Should(Succeed())
+	})
+})
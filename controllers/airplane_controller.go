@@ -18,17 +18,27 @@ package controllers
 
 import (
 	"context"
+	"fmt"
 	"reflect"
 	"strings"
+	"time"
 
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	playv1alpha1 "github.com/roehrich-hpe/airplane-sim/api/v1alpha1"
 )
@@ -36,13 +46,27 @@ import (
 // AirplaneReconciler reconciles a Airplane object
 type AirplaneReconciler struct {
 	client.Client
-	Scheme *runtime.Scheme
-	Log    logr.Logger
+	Scheme   *runtime.Scheme
+	Log      logr.Logger
+	Recorder record.EventRecorder
+
+	// APIReader reads the final snapshot straight from the API server, so
+	// the manager doesn't have to cache every ConfigMap in the cluster.
+	APIReader client.Reader
+
+	// TeardownTimeout is how long a deleted airplane waits for its
+	// surfaces to stow before it reports that it is stuck.  Zero means
+	// the default of a minute.
+	TeardownTimeout time.Duration
 }
 
 //+kubebuilder:rbac:groups=play.github.com,resources=airplanes,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=play.github.com,resources=airplanes/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=play.github.com,resources=airplanes/finalizers,verbs=update
+//+kubebuilder:rbac:groups=play.github.com,resources=aircrafttypes,verbs=get;list;watch
+//+kubebuilder:rbac:groups=play.github.com,resources=registrations,verbs=get;list;watch;create;update;delete
+//+kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+//+kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;update
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -62,137 +86,557 @@ func (r *AirplaneReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 		return ctrl.Result{}, client.IgnoreNotFound(err)
 	}
 
-	log.Info("Check parts")
-	// Check the pedals.
-	if requeue, err := r.verifyPedals(ctx, airplane); err != nil {
+	if !airplane.GetDeletionTimestamp().IsZero() {
+		result, err := r.finalize(ctx, airplane)
+		if err != nil {
+			if errors.IsConflict(err) {
+				log.Info("Conflict while tearing down airplane")
+				return ctrl.Result{Requeue: true}, nil
+			}
+			log.Error(err, "Unable to tear down airplane")
+			return ctrl.Result{}, err
+		}
+		return result, nil
+	}
+	if !controllerutil.ContainsFinalizer(airplane, teardownFinalizer) || controllerutil.ContainsFinalizer(airplane, registrationFinalizer) {
+		controllerutil.AddFinalizer(airplane, teardownFinalizer)
+		controllerutil.RemoveFinalizer(airplane, registrationFinalizer)
+		if err := r.Update(ctx, airplane); err != nil {
+			if errors.IsConflict(err) {
+				log.Info("Conflict while adding finalizer")
+				return ctrl.Result{Requeue: true}, nil
+			}
+			log.Error(err, "Unable to add finalizer")
+			return ctrl.Result{}, err
+		}
+	}
+
+	if err := r.claimRegistration(ctx, airplane); err != nil {
+		return r.conflictResult(ctx, airplane, err)
+	}
+
+	aircraftType, err := getAircraftType(ctx, r.Client, airplane)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			log.Info("Did not find aircraft type", "type", airplane.Spec.TypeRef.Name)
+			status := airplane.Status.DeepCopy()
+			status.ObservedGeneration = airplane.Generation
+			setCondition(&status.Conditions, airplane.Generation, playv1alpha1.ConditionAssembled, false, "TypeNotFound", fmt.Sprintf("Aircraft type %q was not found", airplane.Spec.TypeRef.Name))
+			setCondition(&status.Conditions, airplane.Generation, playv1alpha1.ConditionReady, false, "NotAssembled", "")
+			if err := r.updateStatus(ctx, airplane, status); err != nil {
+				return ctrl.Result{}, err
+			}
+			return ctrl.Result{RequeueAfter: time.Second * 10}, nil
+		}
+		log.Error(err, "Unable to get aircraft type")
 		return ctrl.Result{}, err
-	} else if requeue {
-		return ctrl.Result{Requeue: true}, nil
 	}
 
-	// Check the rudder.
-	if requeue, err := r.verifyRudder(ctx, airplane); err != nil {
+	log.Info("Check parts")
+	parts := []func(context.Context, *playv1alpha1.Airplane, *playv1alpha1.AircraftTypeSpec) (bool, error){
+		// Parts that are named after an old tail number are moved
+		// before the rest are checked, or they'd be built again.
+		r.reregister,
+		r.verifyAddress,
+		// The rudder goes in before the pedals, so the pedal linkage
+		// has something to connect to.
+		r.verifyRudder,
+		r.verifyPedals,
+		r.verifyYoke,
+		r.verifyAileron,
+		r.verifyElevator,
+		r.verifyTrimWheel,
+	}
+	if aircraftType.Flaps != nil {
+		parts = append(parts, r.verifyFlapLever, r.verifyFlaps)
+	}
+	if aircraftType.LandingGear != nil {
+		parts = append(parts, r.verifyGearLever, r.verifyLandingGear, r.summarizeGear)
+	}
+	for _, verify := range parts {
+		if requeue, err := verify(ctx, airplane, aircraftType); err != nil {
+			return r.conflictResult(ctx, airplane, err)
+		} else if requeue {
+			return ctrl.Result{Requeue: true}, nil
+		}
+	}
+
+	if err := r.updateConditions(ctx, airplane); err != nil {
 		return ctrl.Result{}, err
-	} else if requeue {
-		return ctrl.Result{Requeue: true}, nil
 	}
 
 	return ctrl.Result{}, nil
 }
 
+// getAircraftType returns the description of the airplane's parts.  An
+// airplane without a type gets every part, with default parameters.
+func getAircraftType(ctx context.Context, c client.Reader, airplane *playv1alpha1.Airplane) (*playv1alpha1.AircraftTypeSpec, error) {
+	if airplane.Spec.TypeRef == nil {
+		return &playv1alpha1.AircraftTypeSpec{
+			Flaps:       &playv1alpha1.FlapsParameters{},
+			LandingGear: &playv1alpha1.LandingGearParameters{},
+		}, nil
+	}
+
+	aircraftType := &playv1alpha1.AircraftType{}
+	if err := c.Get(ctx, types.NamespacedName{Name: airplane.Spec.TypeRef.Name}, aircraftType); err != nil {
+		return nil, err
+	}
+	return &aircraftType.Spec, nil
+}
+
+// partMeta names a part that belongs to the airplane.  All of an airplane's
+// parts are named after its tail number.
+func partMeta(airplane *playv1alpha1.Airplane) metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Name:      strings.ToLower(airplane.Spec.TailNumber),
+		Namespace: airplane.GetNamespace(),
+	}
+}
+
 // Create the pedals resource if it doesn't aleady exist.  Hook up the pedals
 // to the airplane.
-func (r *AirplaneReconciler) verifyPedals(ctx context.Context, airplane *playv1alpha1.Airplane) (bool, error) {
-	log := r.Log.WithName("pedals")
-
+func (r *AirplaneReconciler) verifyPedals(ctx context.Context, airplane *playv1alpha1.Airplane, aircraftType *playv1alpha1.AircraftTypeSpec) (bool, error) {
 	pedals := &playv1alpha1.Pedals{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      strings.ToLower(airplane.Spec.TailNumber),
-			Namespace: airplane.GetNamespace(),
+		ObjectMeta: partMeta(airplane),
+		Spec: playv1alpha1.PedalsSpec{
+			Pressed: "none",
 		},
 	}
+	return r.verifyPart(ctx, airplane, pedals, &airplane.Status.Pedals)
+}
 
-	// First check whether it exists. Maybe it was orphaned
-	// on an earlier pass.
-	if err := r.Get(ctx, client.ObjectKeyFromObject(pedals), pedals); err != nil {
-		if !errors.IsNotFound(err) {
-			log.Error(err, "Unable to verify existence of pedals")
-			return false, err
-		}
+// Create the rudder resource if it doesn't aleady exist.  Hook up the rudder
+// to the airplane.  A travel limit the aircraft type leaves out takes the
+// rudder's default.
+func (r *AirplaneReconciler) verifyRudder(ctx context.Context, airplane *playv1alpha1.Airplane, aircraftType *playv1alpha1.AircraftTypeSpec) (bool, error) {
+	rudder := &playv1alpha1.Rudder{
+		ObjectMeta: partMeta(airplane),
+		Spec: playv1alpha1.RudderSpec{
+			Position:      "neutral",
+			MinDeflection: aircraftType.Rudder.MinDeflection,
+			MaxDeflection: aircraftType.Rudder.MaxDeflection,
+			SlewRate:      aircraftType.Rudder.SlewRate,
+		},
+	}
+	return r.verifyPart(ctx, airplane, rudder, &airplane.Status.Rudder)
+}
 
-		// It doesn't exist, so create it.
-		ctrl.SetControllerReference(airplane, pedals, r.Scheme)
-		pedals.Spec.Pressed = "none"
-		if err := r.Create(ctx, pedals); err != nil {
-			log.Error(err, "Unable to create pedal`s")
-			return false, err
-		}
-		log.Info("Created pedals", "pedals", pedals)
+// Create the yoke resource if it doesn't aleady exist.  Hook up the yoke
+// to the airplane.
+func (r *AirplaneReconciler) verifyYoke(ctx context.Context, airplane *playv1alpha1.Airplane, aircraftType *playv1alpha1.AircraftTypeSpec) (bool, error) {
+	yoke := &playv1alpha1.Yoke{
+		ObjectMeta: partMeta(airplane),
 	}
+	return r.verifyPart(ctx, airplane, yoke, &airplane.Status.Yoke)
+}
 
-	// Hook up the pedals to the airplane, if it isn't already.
-	pedalsRef := corev1.ObjectReference{
-		Kind:      reflect.TypeOf(playv1alpha1.Pedals{}).Name(),
-		Name:      pedals.GetName(),
-		Namespace: pedals.GetNamespace(),
+// Create the aileron resource if it doesn't aleady exist.  Hook up the
+// ailerons to the airplane.
+func (r *AirplaneReconciler) verifyAileron(ctx context.Context, airplane *playv1alpha1.Airplane, aircraftType *playv1alpha1.AircraftTypeSpec) (bool, error) {
+	aileron := &playv1alpha1.Aileron{
+		ObjectMeta: partMeta(airplane),
+		Spec: playv1alpha1.AileronSpec{
+			MinDeflection: surfaceLimit(aircraftType.Aileron.MinDeflection),
+			MaxDeflection: surfaceLimit(aircraftType.Aileron.MaxDeflection),
+			SlewRate:      aircraftType.Aileron.SlewRate,
+		},
 	}
-	if airplane.Status.Pedals == pedalsRef {
-		// All good.
+	return r.verifyPart(ctx, airplane, aileron, &airplane.Status.Aileron)
+}
+
+// surfaceLimit is a travel limit from an aircraft type, for a surface that
+// takes its default for a limit of zero.
+func surfaceLimit(limit *int32) int32 {
+	if limit == nil {
+		return 0
+	}
+	return *limit
+}
+
+// Create the elevator resource if it doesn't aleady exist.  Hook up the
+// elevator to the airplane.
+func (r *AirplaneReconciler) verifyElevator(ctx context.Context, airplane *playv1alpha1.Airplane, aircraftType *playv1alpha1.AircraftTypeSpec) (bool, error) {
+	elevator := &playv1alpha1.Elevator{
+		ObjectMeta: partMeta(airplane),
+		Spec: playv1alpha1.ElevatorSpec{
+			MinDeflection: surfaceLimit(aircraftType.Elevator.MinDeflection),
+			MaxDeflection: surfaceLimit(aircraftType.Elevator.MaxDeflection),
+			MaxTrim:       aircraftType.Elevator.MaxTrim,
+			SlewRate:      aircraftType.Elevator.SlewRate,
+		},
+	}
+	return r.verifyPart(ctx, airplane, elevator, &airplane.Status.Elevator)
+}
+
+// Create the trim wheel resource if it doesn't aleady exist.  Hook up the
+// trim wheel to the airplane.
+func (r *AirplaneReconciler) verifyTrimWheel(ctx context.Context, airplane *playv1alpha1.Airplane, aircraftType *playv1alpha1.AircraftTypeSpec) (bool, error) {
+	trimWheel := &playv1alpha1.TrimWheel{
+		ObjectMeta: partMeta(airplane),
+	}
+	return r.verifyPart(ctx, airplane, trimWheel, &airplane.Status.TrimWheel)
+}
+
+// Create the flap lever resource if it doesn't aleady exist.  Hook up the
+// flap lever to the airplane.
+func (r *AirplaneReconciler) verifyFlapLever(ctx context.Context, airplane *playv1alpha1.Airplane, aircraftType *playv1alpha1.AircraftTypeSpec) (bool, error) {
+	flapLever := &playv1alpha1.FlapLever{
+		ObjectMeta: partMeta(airplane),
+	}
+	return r.verifyPart(ctx, airplane, flapLever, &airplane.Status.FlapLever)
+}
+
+// Create the flaps resource if it doesn't aleady exist.  Hook up the flaps
+// to the airplane.
+func (r *AirplaneReconciler) verifyFlaps(ctx context.Context, airplane *playv1alpha1.Airplane, aircraftType *playv1alpha1.AircraftTypeSpec) (bool, error) {
+	flaps := &playv1alpha1.Flaps{
+		ObjectMeta: partMeta(airplane),
+		Spec: playv1alpha1.FlapsSpec{
+			MaxPosition:       aircraftType.Flaps.MaxPosition,
+			ExtensionRate:     aircraftType.Flaps.ExtensionRate,
+			MaxExtensionSpeed: aircraftType.Flaps.MaxExtensionSpeed,
+		},
+	}
+	return r.verifyPart(ctx, airplane, flaps, &airplane.Status.Flaps)
+}
+
+// Create the gear lever resource if it doesn't aleady exist.  Hook up the
+// gear lever to the airplane.
+func (r *AirplaneReconciler) verifyGearLever(ctx context.Context, airplane *playv1alpha1.Airplane, aircraftType *playv1alpha1.AircraftTypeSpec) (bool, error) {
+	gearLever := &playv1alpha1.GearLever{
+		ObjectMeta: partMeta(airplane),
+		Spec: playv1alpha1.GearLeverSpec{
+			Position: "down",
+		},
+	}
+	return r.verifyPart(ctx, airplane, gearLever, &airplane.Status.GearLever)
+}
+
+// Create the landing gear resource if it doesn't aleady exist.  Hook up the
+// landing gear to the airplane.
+func (r *AirplaneReconciler) verifyLandingGear(ctx context.Context, airplane *playv1alpha1.Airplane, aircraftType *playv1alpha1.AircraftTypeSpec) (bool, error) {
+	landingGear := &playv1alpha1.LandingGear{
+		ObjectMeta: partMeta(airplane),
+		Spec: playv1alpha1.LandingGearSpec{
+			Position:       "down",
+			ExtensionTime:  aircraftType.LandingGear.ExtensionTime,
+			RetractionTime: aircraftType.LandingGear.RetractionTime,
+		},
+	}
+	return r.verifyPart(ctx, airplane, landingGear, &airplane.Status.LandingGear)
+}
+
+// Copy the state of the gear lever and landing gear into the airplane's
+// gear summary.
+func (r *AirplaneReconciler) summarizeGear(ctx context.Context, airplane *playv1alpha1.Airplane, aircraftType *playv1alpha1.AircraftTypeSpec) (bool, error) {
+	log := r.Log.WithName("gear")
+
+	gearLever := &playv1alpha1.GearLever{}
+	gearLeverKey := types.NamespacedName{Name: airplane.Status.GearLever.Name, Namespace: airplane.GetNamespace()}
+	if err := r.Get(ctx, gearLeverKey, gearLever); err != nil {
+		return false, client.IgnoreNotFound(err)
+	}
+	landingGear := &playv1alpha1.LandingGear{}
+	landingGearKey := types.NamespacedName{Name: airplane.Status.LandingGear.Name, Namespace: airplane.GetNamespace()}
+	if err := r.Get(ctx, landingGearKey, landingGear); err != nil {
+		return false, client.IgnoreNotFound(err)
+	}
+
+	summary := playv1alpha1.GearSummary{
+		Lever:             gearLever.Status.Position,
+		State:             landingGear.Status.State,
+		RetractionBlocked: landingGear.Status.RetractionBlocked,
+	}
+	if airplane.Status.Gear == summary {
 		return false, nil
 	}
-	airplane.Status.Pedals = pedalsRef
+	airplane.Status.Gear = summary
 	if err := r.Status().Update(ctx, airplane); err != nil {
-		// We created the pedals above, but we weren't able to
-		// hook it up to the airplane this time.  We'll go
-		// around again, find the pedals, and try to hook
-		// it up.
-		log.Error(err, "Unable to set pedals reference in airplane")
+		log.Error(err, "Unable to set gear summary in airplane")
 		return false, err
 	}
-	log.Info("Hooked up pedals to airplane")
+	log.Info("Updated gear summary", "state", summary.State)
 
 	return true, nil
 }
 
-// Create the rudder resource if it doesn't aleady exist.  Hook up the rudder
-// to the airplane.
-func (r *AirplaneReconciler) verifyRudder(ctx context.Context, airplane *playv1alpha1.Airplane) (bool, error) {
-	log := r.Log.WithName("rudder")
+// controlLinkage is one of the built-in linkages from a control in the
+// cockpit to the surface it moves.
+type controlLinkage struct {
+	reason  string
+	message string
+	control string
+	surface client.Object
+}
 
-	rudder := &playv1alpha1.Rudder{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      strings.ToLower(airplane.Spec.TailNumber),
-			Namespace: airplane.GetNamespace(),
-		},
+// unlinkedControl returns the first of the airplane's controls, other than
+// the pedals, whose linkage doesn't reach its surface.  A built-in linkage
+// drives the surface with the same name as its control, so that surface has
+// to be the airplane's own and it has to exist.  A linkage is skipped when
+// the airplane's type has neither the control nor the surface, as with
+// fixed gear.
+func (r *AirplaneReconciler) unlinkedControl(ctx context.Context, airplane *playv1alpha1.Airplane) (*controlLinkage, error) {
+	parts := &airplane.Status
+	linkages := []struct {
+		controlLinkage
+		surfaceRef corev1.ObjectReference
+	}{
+		{controlLinkage{"AileronNotLinked", "The yoke is not linked to the aileron", parts.Yoke.Name, &playv1alpha1.Aileron{}}, parts.Aileron},
+		{controlLinkage{"ElevatorNotLinked", "The yoke is not linked to the elevator", parts.Yoke.Name, &playv1alpha1.Elevator{}}, parts.Elevator},
+		{controlLinkage{"TrimNotLinked", "The trim wheel is not linked to the elevator", parts.TrimWheel.Name, &playv1alpha1.Elevator{}}, parts.Elevator},
+		{controlLinkage{"FlapsNotLinked", "The flap lever is not linked to the flaps", parts.FlapLever.Name, &playv1alpha1.Flaps{}}, parts.Flaps},
+		{controlLinkage{"GearNotLinked", "The gear lever is not linked to the landing gear", parts.GearLever.Name, &playv1alpha1.LandingGear{}}, parts.LandingGear},
+	}
+	for i := range linkages {
+		linkage := &linkages[i]
+		if len(linkage.control) == 0 && len(linkage.surfaceRef.Name) == 0 {
+			continue
+		}
+		if len(linkage.control) == 0 || linkage.control != linkage.surfaceRef.Name {
+			return &linkage.controlLinkage, nil
+		}
+		key := types.NamespacedName{Name: linkage.control, Namespace: airplane.GetNamespace()}
+		if err := r.Get(ctx, key, linkage.surface); err != nil {
+			if errors.IsNotFound(err) {
+				return &linkage.controlLinkage, nil
+			}
+			return nil, err
+		}
+	}
+	return nil, nil
+}
+
+// Report whether the airplane is assembled, whether its controls are linked,
+// and whether it is ready to fly.  This runs once all of the parts are in
+// place.
+func (r *AirplaneReconciler) updateConditions(ctx context.Context, airplane *playv1alpha1.Airplane) error {
+	generation := airplane.Generation
+	status := airplane.Status.DeepCopy()
+	status.ObservedGeneration = generation
+	setCondition(&status.Conditions, generation, playv1alpha1.ConditionAssembled, true, "PartsInstalled", "")
+	setCondition(&status.Conditions, generation, playv1alpha1.ConditionConflict, false, "NoConflict", "")
+
+	pedals := &playv1alpha1.Pedals{}
+	pedalsKey := types.NamespacedName{Name: airplane.Status.Pedals.Name, Namespace: airplane.GetNamespace()}
+	if err := r.Get(ctx, pedalsKey, pedals); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	linked := meta.IsStatusConditionTrue(pedals.Status.Conditions, playv1alpha1.ConditionControlsLinked)
+	if !linked {
+		setCondition(&status.Conditions, generation, playv1alpha1.ConditionControlsLinked, false, "PedalsNotLinked", "The pedals are not linked to the rudder")
+	} else if unlinked, err := r.unlinkedControl(ctx, airplane); err != nil {
+		return err
+	} else if unlinked != nil {
+		linked = false
+		setCondition(&status.Conditions, generation, playv1alpha1.ConditionControlsLinked, false, unlinked.reason, unlinked.message)
+	} else {
+		setCondition(&status.Conditions, generation, playv1alpha1.ConditionControlsLinked, true, "Linked", "")
+	}
+
+	degraded := status.Gear.State == playv1alpha1.GearUnsafe
+	if degraded {
+		setCondition(&status.Conditions, generation, playv1alpha1.ConditionDegraded, true, "GearUnsafe", "The landing gear failed to lock")
+	} else {
+		setCondition(&status.Conditions, generation, playv1alpha1.ConditionDegraded, false, "NoFaults", "")
 	}
 
+	switch {
+	case !linked:
+		setCondition(&status.Conditions, generation, playv1alpha1.ConditionReady, false, "ControlsNotLinked", "")
+	case degraded:
+		setCondition(&status.Conditions, generation, playv1alpha1.ConditionReady, false, "Degraded", "")
+	default:
+		setCondition(&status.Conditions, generation, playv1alpha1.ConditionReady, true, "Ready", "")
+	}
+
+	return r.updateStatus(ctx, airplane, status)
+}
+
+// Write the airplane's new status, if it changed, and publish whether the
+// airplane is ready.
+func (r *AirplaneReconciler) updateStatus(ctx context.Context, airplane *playv1alpha1.Airplane, status *playv1alpha1.AirplaneStatus) error {
+	log := r.Log.WithName("conditions")
+
+	ready := 0.0
+	if meta.IsStatusConditionTrue(status.Conditions, playv1alpha1.ConditionReady) {
+		ready = 1
+	}
+	airplaneReadyMetric.With(airplaneLabels(airplane)).Set(ready)
+
+	if equality.Semantic.DeepEqual(status, &airplane.Status) {
+		return nil
+	}
+	airplane.Status = *status
+	if err := r.Status().Update(ctx, airplane); err != nil {
+		log.Error(err, "Unable to set conditions in airplane")
+		return err
+	}
+	log.Info("Updated conditions")
+
+	return nil
+}
+
+// Create the part if it doesn't already exist, using the name and spec that
+// the caller filled in.  Hook up the part to the airplane through the
+// given reference in the airplane's status.
+func (r *AirplaneReconciler) verifyPart(ctx context.Context, airplane *playv1alpha1.Airplane, part client.Object, ref *corev1.ObjectReference) (bool, error) {
+	kind := reflect.TypeOf(part).Elem().Name()
+	name := strings.ToLower(kind)
+	log := r.Log.WithName(name)
+
 	// First check whether it exists. Maybe it was orphaned
 	// on an earlier pass.
-	if err := r.Get(ctx, client.ObjectKeyFromObject(rudder), rudder); err != nil {
+	if err := r.Get(ctx, client.ObjectKeyFromObject(part), part); err != nil {
 		if !errors.IsNotFound(err) {
-			log.Error(err, "Unable to verify existence of rudder")
+			log.Error(err, "Unable to verify existence of "+name)
 			return false, err
 		}
 
 		// It doesn't exist, so create it.
-		ctrl.SetControllerReference(airplane, rudder, r.Scheme)
-		rudder.Spec.Position = "neutral"
-		if err := r.Create(ctx, rudder); err != nil {
-			log.Error(err, "Unable to create rudder")
+		ctrl.SetControllerReference(airplane, part, r.Scheme)
+		if err := r.Create(ctx, part); err != nil {
+			log.Error(err, "Unable to create "+name)
 			return false, err
 		}
-		log.Info("Created rudder", "rudder", rudder)
+		log.Info("Created "+name, name, part)
+		r.Recorder.Eventf(airplane, corev1.EventTypeNormal, playv1alpha1.EventPartCreated, "Created %s %s", name, part.GetName())
+	} else if err := checkOwner(airplane, part); err != nil {
+		return false, err
 	}
 
-	// Hook up the rudder to the airplane, if it isn't already.
-	rudderRef := corev1.ObjectReference{
-		Kind:      reflect.TypeOf(playv1alpha1.Rudder{}).Name(),
-		Name:      rudder.GetName(),
-		Namespace: rudder.GetNamespace(),
+	// Hook up the part to the airplane, if it isn't already.
+	partRef := corev1.ObjectReference{
+		Kind:      kind,
+		Name:      part.GetName(),
+		Namespace: part.GetNamespace(),
 	}
-	if airplane.Status.Rudder == rudderRef {
+	if *ref == partRef {
 		// All good.
 		return false, nil
 	}
-	airplane.Status.Rudder = rudderRef
+	*ref = partRef
 	if err := r.Status().Update(ctx, airplane); err != nil {
-		// We created the rudder above, but we weren't able to
+		// We created the part above, but we weren't able to
 		// hook it up to the airplane this time.  We'll go
-		// around again, find the rudder, and try to hook
+		// around again, find the part, and try to hook
 		// it up.
-		log.Error(err, "Unable to set rudder reference in airplane")
+		log.Error(err, "Unable to set "+name+" reference in airplane")
 		return false, err
 	}
-	log.Info("Hooked up rudder to airplane")
+	log.Info("Hooked up " + name + " to airplane")
+	r.Recorder.Eventf(airplane, corev1.EventTypeNormal, playv1alpha1.EventPartLinked, "Hooked up %s %s", name, part.GetName())
 
 	return true, nil
 }
 
+// partConflict is returned when a part or registration that the airplane
+// would use is held by something other than the airplane.  The reason is
+// used for both the condition and the event.
+type partConflict struct {
+	reason string
+	kind   string
+	name   string
+	holder string
+}
+
+func (c *partConflict) Error() string {
+	if len(c.holder) == 0 {
+		return fmt.Sprintf("%s %q already exists and does not belong to an airplane", c.kind, c.name)
+	}
+	return fmt.Sprintf("%s %q belongs to %s", c.kind, c.name, c.holder)
+}
+
+// checkOwner makes sure the airplane is the controller of an existing part.
+// The airplane won't adopt a part that it didn't create, whether another
+// airplane controls it or someone made it by hand.
+func checkOwner(airplane *playv1alpha1.Airplane, part client.Object) error {
+	owner := metav1.GetControllerOf(part)
+	if owner != nil && owner.UID == airplane.GetUID() {
+		return nil
+	}
+	conflict := &partConflict{
+		reason: "PartConflict",
+		kind:   reflect.TypeOf(part).Elem().Name(),
+		name:   part.GetName(),
+	}
+	if owner != nil {
+		conflict.holder = fmt.Sprintf("%s %q", owner.Kind, owner.Name)
+	}
+	return conflict
+}
+
+// conflictResult reports a conflict, if that's what the error is, and waits
+// for it to clear.  Any other error is returned for the reconciler to retry.
+func (r *AirplaneReconciler) conflictResult(ctx context.Context, airplane *playv1alpha1.Airplane, err error) (ctrl.Result, error) {
+	conflict, ok := err.(*partConflict)
+	if !ok {
+		return ctrl.Result{}, err
+	}
+	if err := r.reportConflict(ctx, airplane, conflict); err != nil {
+		return ctrl.Result{}, err
+	}
+	// Nothing tells this airplane when the other owner lets go of a
+	// part, so check back.
+	return ctrl.Result{RequeueAfter: time.Second * 10}, nil
+}
+
+// Report that the airplane can't be assembled because one of its parts, or
+// its registration, is taken.  The event goes out only when the conflict is
+// new, so an airplane waiting for the part doesn't repeat itself.
+func (r *AirplaneReconciler) reportConflict(ctx context.Context, airplane *playv1alpha1.Airplane, conflict *partConflict) error {
+	generation := airplane.Generation
+	message := conflict.Error()
+
+	existing := meta.FindStatusCondition(airplane.Status.Conditions, playv1alpha1.ConditionConflict)
+	if existing == nil || existing.Status != metav1.ConditionTrue || existing.Message != message {
+		r.Log.Info("Conflict", "kind", conflict.kind, "name", conflict.name)
+		r.Recorder.Event(airplane, corev1.EventTypeWarning, conflict.reason, message)
+	}
+
+	status := airplane.Status.DeepCopy()
+	status.ObservedGeneration = generation
+	setCondition(&status.Conditions, generation, playv1alpha1.ConditionConflict, true, conflict.reason, message)
+	setCondition(&status.Conditions, generation, playv1alpha1.ConditionAssembled, false, "Conflict", message)
+	setCondition(&status.Conditions, generation, playv1alpha1.ConditionReady, false, "NotAssembled", "")
+	return r.updateStatus(ctx, airplane, status)
+}
+
+// airplanesForType maps an aircraft type to the airplanes of that type, so
+// airplanes that were waiting for their type can be assembled.
+func (r *AirplaneReconciler) airplanesForType(obj client.Object) []reconcile.Request {
+	airplanes := &playv1alpha1.AirplaneList{}
+	if err := r.List(context.Background(), airplanes); err != nil {
+		return nil
+	}
+
+	requests := []reconcile.Request{}
+	for _, airplane := range airplanes.Items {
+		if airplane.Spec.TypeRef != nil && airplane.Spec.TypeRef.Name == obj.GetName() {
+			requests = append(requests, reconcile.Request{
+				NamespacedName: client.ObjectKeyFromObject(&airplane),
+			})
+		}
+	}
+	return requests
+}
+
 // SetupWithManager sets up the controller with the Manager.
 func (r *AirplaneReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&playv1alpha1.Airplane{}).
 		Owns(&playv1alpha1.Pedals{}).
 		Owns(&playv1alpha1.Rudder{}).
+		Owns(&playv1alpha1.Yoke{}).
+		Owns(&playv1alpha1.Aileron{}).
+		Owns(&playv1alpha1.Elevator{}).
+		Owns(&playv1alpha1.TrimWheel{}).
+		Owns(&playv1alpha1.FlapLever{}).
+		Owns(&playv1alpha1.Flaps{}).
+		Owns(&playv1alpha1.GearLever{}).
+		Owns(&playv1alpha1.LandingGear{}).
+		Watches(&source.Kind{Type: &playv1alpha1.AircraftType{}}, handler.EnqueueRequestsFromMapFunc(r.airplanesForType)).
+		Watches(&source.Kind{Type: &playv1alpha1.Registration{}}, handler.EnqueueRequestsFromMapFunc(r.airplanesForRegistration)).
+		Watches(&source.Kind{Type: &playv1alpha1.SimClock{}}, handler.EnqueueRequestsFromMapFunc(clockWatcher(mgr.GetClient(), &playv1alpha1.AirplaneList{}))).
 		Complete(r)
 }
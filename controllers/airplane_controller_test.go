@@ -20,17 +20,25 @@ import (
 	"context"
 	"reflect"
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
 
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	playv1alpha1 "github.com/roehrich-hpe/airplane-sim/api/v1alpha1"
+	"github.com/roehrich-hpe/airplane-sim/pkg/icao"
 )
 
 var _ = Describe("Airplane unit tests for initial population", func() {
@@ -85,12 +93,19 @@ var _ = Describe("Airplane unit tests for initial population", func() {
 			},
 			Entry("when empty", "", false),
 			Entry("when lowercase N", "n123AB", false),
-			Entry("when too short", "N1234", false),
 			Entry("when no leading N", "z123AB", false),
 			Entry("when some lowercase", "N123ab", false),
 			Entry("when too long", "N123ABC", false),
+			Entry("when leading zero", "N0ABCD", false),
+			Entry("when letter I", "N12I", false),
+			Entry("when letter O", "N12O", false),
+			Entry("when letter in the middle", "N1A23", false),
 			Entry("when good", "N123BC", true),
 			Entry("when good", "N901NV", true),
+			Entry("when short", "N1234", true),
+			Entry("when one digit", "N1", true),
+			Entry("when two letters", "N12AB", true),
+			Entry("when from another register", "G-ABCD", true),
 		)
 	})
 })
@@ -111,7 +126,7 @@ var _ = Describe("Airplane unit tests", func() {
 			Namespace: corev1.NamespaceDefault,
 		}
 
-		ucTailNumber = "N" + strings.ToUpper(uuid.New().String()[0:5])
+		ucTailNumber = newTailNumber()
 		tailNumber = strings.ToLower(ucTailNumber)
 
 		airplane = &playv1alpha1.Airplane{
@@ -135,11 +150,13 @@ var _ = Describe("Airplane unit tests", func() {
 
 	AfterEach(func() {
 		// The airplane should appear as the owner of its components.
-		// In the test control plane the components won't be cleaned
-		// up by garbage collection, so instead we settle for verifing
-		// that they have the owner reference that would be used by
-		// garbage collection.
-		Expect(k8sClient.Get(context.TODO(), key, airplane)).To(Succeed())
+		// The airplane deletes its components itself as it is torn
+		// down, but they still carry the owner reference that garbage
+		// collection would use if the finalizer were removed by hand.
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(context.TODO(), key, airplane)).To(Succeed())
+			g.Expect(airplane.Status.LandingGear.Name).To(Equal(tailNumber))
+		}).WithTimeout(5 * time.Second).Should(Succeed())
 		var truePtr bool = true
 		expectedOwnerReference := v1.OwnerReference{
 			Kind:               reflect.TypeOf(*airplane).Name(),
@@ -160,6 +177,46 @@ var _ = Describe("Airplane unit tests", func() {
 		Expect(rudder.GetOwnerReferences()).To(HaveLen(1))
 		Expect(rudder.GetOwnerReferences()).To(ContainElement(expectedOwnerReference))
 
+		yoke := &playv1alpha1.Yoke{}
+		Expect(k8sClient.Get(context.TODO(), ckey, yoke)).To(Succeed())
+		Expect(yoke.GetOwnerReferences()).To(HaveLen(1))
+		Expect(yoke.GetOwnerReferences()).To(ContainElement(expectedOwnerReference))
+
+		aileron := &playv1alpha1.Aileron{}
+		Expect(k8sClient.Get(context.TODO(), ckey, aileron)).To(Succeed())
+		Expect(aileron.GetOwnerReferences()).To(HaveLen(1))
+		Expect(aileron.GetOwnerReferences()).To(ContainElement(expectedOwnerReference))
+
+		elevator := &playv1alpha1.Elevator{}
+		Expect(k8sClient.Get(context.TODO(), ckey, elevator)).To(Succeed())
+		Expect(elevator.GetOwnerReferences()).To(HaveLen(1))
+		Expect(elevator.GetOwnerReferences()).To(ContainElement(expectedOwnerReference))
+
+		trimWheel := &playv1alpha1.TrimWheel{}
+		Expect(k8sClient.Get(context.TODO(), ckey, trimWheel)).To(Succeed())
+		Expect(trimWheel.GetOwnerReferences()).To(HaveLen(1))
+		Expect(trimWheel.GetOwnerReferences()).To(ContainElement(expectedOwnerReference))
+
+		flapLever := &playv1alpha1.FlapLever{}
+		Expect(k8sClient.Get(context.TODO(), ckey, flapLever)).To(Succeed())
+		Expect(flapLever.GetOwnerReferences()).To(HaveLen(1))
+		Expect(flapLever.GetOwnerReferences()).To(ContainElement(expectedOwnerReference))
+
+		flaps := &playv1alpha1.Flaps{}
+		Expect(k8sClient.Get(context.TODO(), ckey, flaps)).To(Succeed())
+		Expect(flaps.GetOwnerReferences()).To(HaveLen(1))
+		Expect(flaps.GetOwnerReferences()).To(ContainElement(expectedOwnerReference))
+
+		gearLever := &playv1alpha1.GearLever{}
+		Expect(k8sClient.Get(context.TODO(), ckey, gearLever)).To(Succeed())
+		Expect(gearLever.GetOwnerReferences()).To(HaveLen(1))
+		Expect(gearLever.GetOwnerReferences()).To(ContainElement(expectedOwnerReference))
+
+		landingGear := &playv1alpha1.LandingGear{}
+		Expect(k8sClient.Get(context.TODO(), ckey, landingGear)).To(Succeed())
+		Expect(landingGear.GetOwnerReferences()).To(HaveLen(1))
+		Expect(landingGear.GetOwnerReferences()).To(ContainElement(expectedOwnerReference))
+
 		// Now delete the airplane.
 		Expect(k8sClient.Delete(context.TODO(), airplane)).To(Succeed())
 
@@ -168,6 +225,10 @@ var _ = Describe("Airplane unit tests", func() {
 		Eventually(func() error {
 			return k8sClient.Get(context.TODO(), key, expected)
 		}).ShouldNot(Succeed())
+
+		// The components went first.
+		Expect(k8sClient.Get(context.TODO(), ckey, &playv1alpha1.Rudder{})).To(Satisfy(apierrors.IsNotFound))
+		Expect(k8sClient.Get(context.TODO(), ckey, &playv1alpha1.LandingGear{})).To(Satisfy(apierrors.IsNotFound))
 	})
 
 	It("Creates pedals and rudder", func() {
@@ -182,4 +243,699 @@ var _ = Describe("Airplane unit tests", func() {
 		rudder := &playv1alpha1.Rudder{}
 		Expect(k8sClient.Get(context.TODO(), ckey, rudder)).To(Succeed())
 	})
+
+	It("Creates yoke and ailerons", func() {
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(context.TODO(), key, airplane)).To(Succeed())
+			g.Expect(airplane.Status.Yoke.Name).To(Equal(tailNumber))
+			g.Expect(airplane.Status.Aileron.Name).To(Equal(tailNumber))
+		}).Should(Succeed())
+
+		yoke := &playv1alpha1.Yoke{}
+		Expect(k8sClient.Get(context.TODO(), ckey, yoke)).To(Succeed())
+		aileron := &playv1alpha1.Aileron{}
+		Expect(k8sClient.Get(context.TODO(), ckey, aileron)).To(Succeed())
+	})
+
+	It("Creates elevator and trim wheel", func() {
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(context.TODO(), key, airplane)).To(Succeed())
+			g.Expect(airplane.Status.Elevator.Name).To(Equal(tailNumber))
+			g.Expect(airplane.Status.TrimWheel.Name).To(Equal(tailNumber))
+		}).Should(Succeed())
+
+		elevator := &playv1alpha1.Elevator{}
+		Expect(k8sClient.Get(context.TODO(), ckey, elevator)).To(Succeed())
+		trimWheel := &playv1alpha1.TrimWheel{}
+		Expect(k8sClient.Get(context.TODO(), ckey, trimWheel)).To(Succeed())
+	})
+
+	It("Creates flap lever and flaps", func() {
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(context.TODO(), key, airplane)).To(Succeed())
+			g.Expect(airplane.Status.FlapLever.Name).To(Equal(tailNumber))
+			g.Expect(airplane.Status.Flaps.Name).To(Equal(tailNumber))
+		}).Should(Succeed())
+
+		flapLever := &playv1alpha1.FlapLever{}
+		Expect(k8sClient.Get(context.TODO(), ckey, flapLever)).To(Succeed())
+		flaps := &playv1alpha1.Flaps{}
+		Expect(k8sClient.Get(context.TODO(), ckey, flaps)).To(Succeed())
+	})
+
+	It("Creates gear lever and landing gear", func() {
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(context.TODO(), key, airplane)).To(Succeed())
+			g.Expect(airplane.Status.GearLever.Name).To(Equal(tailNumber))
+			g.Expect(airplane.Status.LandingGear.Name).To(Equal(tailNumber))
+			g.Expect(airplane.Status.Gear.Lever).To(Equal("down"))
+			g.Expect(airplane.Status.Gear.State).To(Equal(playv1alpha1.GearDownLocked))
+		}).Should(Succeed())
+
+		gearLever := &playv1alpha1.GearLever{}
+		Expect(k8sClient.Get(context.TODO(), ckey, gearLever)).To(Succeed())
+		landingGear := &playv1alpha1.LandingGear{}
+		Expect(k8sClient.Get(context.TODO(), ckey, landingGear)).To(Succeed())
+	})
+
+	It("Becomes ready once assembled", func() {
+		// Assembly takes a pass for each part.
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(context.TODO(), key, airplane)).To(Succeed())
+			g.Expect(airplane.Status.ObservedGeneration).To(Equal(airplane.Generation))
+			g.Expect(meta.IsStatusConditionTrue(airplane.Status.Conditions, playv1alpha1.ConditionAssembled)).To(BeTrue())
+			g.Expect(meta.IsStatusConditionTrue(airplane.Status.Conditions, playv1alpha1.ConditionControlsLinked)).To(BeTrue())
+			g.Expect(meta.IsStatusConditionFalse(airplane.Status.Conditions, playv1alpha1.ConditionDegraded)).To(BeTrue())
+			g.Expect(meta.IsStatusConditionTrue(airplane.Status.Conditions, playv1alpha1.ConditionReady)).To(BeTrue())
+		}).WithTimeout(5 * time.Second).Should(Succeed())
+	})
+
+	It("Notices a control that is not linked to its surface", func() {
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(context.TODO(), key, airplane)).To(Succeed())
+			g.Expect(meta.IsStatusConditionTrue(airplane.Status.Conditions, playv1alpha1.ConditionReady)).To(BeTrue())
+		}).WithTimeout(5 * time.Second).Should(Succeed())
+
+		r := &AirplaneReconciler{Client: k8sClient}
+		unlinked, err := r.unlinkedControl(context.TODO(), airplane)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(unlinked).To(BeNil())
+
+		By("pointing the airplane at flaps the flap lever doesn't drive")
+		airplane.Status.Flaps.Name = tailNumber + "-other"
+		unlinked, err = r.unlinkedControl(context.TODO(), airplane)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(unlinked).NotTo(BeNil())
+		Expect(unlinked.reason).To(Equal("FlapsNotLinked"))
+	})
+
+	It("Publishes its Mode S address", func() {
+		code, err := icao.Address(ucTailNumber)
+		Expect(err).NotTo(HaveOccurred())
+		address := icao.Hex(code)
+
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(context.TODO(), key, airplane)).To(Succeed())
+			g.Expect(airplane.Status.ICAOAddress).To(Equal(address))
+			g.Expect(airplane.GetLabels()).To(HaveKeyWithValue(playv1alpha1.ICAOAddressLabel, address))
+		}).Should(Succeed())
+
+		airplanes := &playv1alpha1.AirplaneList{}
+		Expect(k8sClient.List(context.TODO(), airplanes, client.MatchingLabels{playv1alpha1.ICAOAddressLabel: address})).To(Succeed())
+		Expect(airplanes.Items).To(HaveLen(1))
+		Expect(airplanes.Items[0].Name).To(Equal(key.Name))
+	})
+
+	It("Exports metrics for its controls", func() {
+		labels := prometheus.Labels{"tail_number": ucTailNumber, "namespace": key.Namespace}
+		Eventually(func() float64 {
+			return testutil.ToFloat64(airplaneReadyMetric.With(labels))
+		}).Should(Equal(1.0))
+
+		By("pressing the right pedal")
+		pedals := &playv1alpha1.Pedals{}
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(context.TODO(), ckey, pedals)).To(Succeed())
+			pedals.Spec.Pressed = "right"
+			g.Expect(k8sClient.Update(context.TODO(), pedals)).To(Succeed())
+		}).Should(Succeed())
+
+		rudder := &playv1alpha1.Rudder{}
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(context.TODO(), ckey, rudder)).To(Succeed())
+			g.Expect(rudder.Status.Position).To(Equal("right"))
+		}).Should(Succeed())
+
+		Eventually(func(g Gomega) {
+			g.Expect(testutil.ToFloat64(pedalTravelMetric.With(labels))).To(Equal(100.0))
+			g.Expect(testutil.ToFloat64(rudderCommandedMetric.With(labels))).To(Equal(float64(*rudder.Spec.MaxDeflection)))
+			g.Expect(testutil.ToFloat64(rudderDeflectionMetric.With(labels))).To(Equal(float64(*rudder.Spec.MaxDeflection)))
+
+			lag := &dto.Metric{}
+			g.Expect(linkageLagMetric.With(labels).(prometheus.Histogram).Write(lag)).To(Succeed())
+			g.Expect(lag.GetHistogram().GetSampleCount()).To(BeNumerically(">=", 1))
+		}).Should(Succeed())
+	})
+
+	It("Records events as it is built and flown", func() {
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(context.TODO(), key, airplane)).To(Succeed())
+			g.Expect(meta.IsStatusConditionTrue(airplane.Status.Conditions, playv1alpha1.ConditionReady)).To(BeTrue())
+		}).WithTimeout(5 * time.Second).Should(Succeed())
+
+		By("pushing the pedals")
+		pedals := &playv1alpha1.Pedals{}
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(context.TODO(), ckey, pedals)).To(Succeed())
+			pedals.Spec.Pressed = "left"
+			g.Expect(k8sClient.Update(context.TODO(), pedals)).To(Succeed())
+		}).Should(Succeed())
+
+		rudder := &playv1alpha1.Rudder{}
+		Expect(k8sClient.Get(context.TODO(), ckey, rudder)).To(Succeed())
+		Eventually(func(g Gomega) {
+			g.Expect(eventReasons(g, rudder)).To(ContainElement(playv1alpha1.EventRudderMoved))
+		}).Should(Succeed())
+
+		By("checking the airplane tells the story")
+		Eventually(func(g Gomega) {
+			g.Expect(eventReasons(g, airplane)).To(ContainElements(
+				playv1alpha1.EventPartCreated,
+				playv1alpha1.EventPartLinked,
+				playv1alpha1.EventRudderMoved,
+			))
+		}).Should(Succeed())
+	})
+
+	It("Moves its parts to a new tail number", func() {
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(context.TODO(), key, airplane)).To(Succeed())
+			g.Expect(airplane.Status.TailNumber).To(Equal(ucTailNumber))
+			g.Expect(airplane.Status.LandingGear.Name).To(Equal(tailNumber))
+		}).WithTimeout(5 * time.Second).Should(Succeed())
+
+		By("pushing the pedals")
+		pedals := &playv1alpha1.Pedals{}
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(context.TODO(), ckey, pedals)).To(Succeed())
+			travel := int32(40)
+			pedals.Spec.Travel = &travel
+			g.Expect(k8sClient.Update(context.TODO(), pedals)).To(Succeed())
+		}).Should(Succeed())
+		rudder := &playv1alpha1.Rudder{}
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(context.TODO(), ckey, rudder)).To(Succeed())
+			g.Expect(rudder.Status.Deflection).To(Equal(int32(10)))
+		}).Should(Succeed())
+
+		By("re-registering the airplane")
+		oldTailNumber := ucTailNumber
+		oldKey := ckey
+		ucTailNumber = newTailNumber()
+		tailNumber = strings.ToLower(ucTailNumber)
+		ckey.Name = tailNumber
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(context.TODO(), key, airplane)).To(Succeed())
+			airplane.Spec.TailNumber = ucTailNumber
+			g.Expect(k8sClient.Update(context.TODO(), airplane)).To(Succeed())
+		}).Should(Succeed())
+
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(context.TODO(), key, airplane)).To(Succeed())
+			g.Expect(airplane.Status.TailNumber).To(Equal(ucTailNumber))
+			g.Expect(airplane.Status.PreviousRegistrations).To(HaveLen(1))
+			g.Expect(airplane.Status.PreviousRegistrations[0].TailNumber).To(Equal(oldTailNumber))
+			g.Expect(airplane.Status.Pedals.Name).To(Equal(tailNumber))
+			g.Expect(airplane.Status.Rudder.Name).To(Equal(tailNumber))
+		}).WithTimeout(3 * time.Second).Should(Succeed())
+
+		By("checking the parts kept their state")
+		Expect(k8sClient.Get(context.TODO(), ckey, pedals)).To(Succeed())
+		Expect(pedals.Spec.Travel).To(HaveValue(Equal(int32(40))))
+		Expect(k8sClient.Get(context.TODO(), ckey, rudder)).To(Succeed())
+		Expect(rudder.Status.Deflection).To(Equal(int32(10)))
+
+		By("checking the registration moved")
+		registration := &playv1alpha1.Registration{}
+		Expect(k8sClient.Get(context.TODO(), types.NamespacedName{Name: tailNumber}, registration)).To(Succeed())
+		Expect(registration.Spec.AirplaneRef.UID).To(Equal(airplane.GetUID()))
+		Eventually(func() error {
+			return k8sClient.Get(context.TODO(), types.NamespacedName{Name: oldKey.Name}, &playv1alpha1.Registration{})
+		}).Should(Satisfy(apierrors.IsNotFound))
+
+		By("checking the old parts are gone")
+		Eventually(func() error {
+			return k8sClient.Get(context.TODO(), oldKey, &playv1alpha1.Pedals{})
+		}).Should(Satisfy(apierrors.IsNotFound))
+		Eventually(func() error {
+			return k8sClient.Get(context.TODO(), oldKey, &playv1alpha1.Rudder{})
+		}).Should(Satisfy(apierrors.IsNotFound))
+	})
+
+	It("Refuses parts that belong to another airplane", func() {
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(context.TODO(), key, airplane)).To(Succeed())
+			g.Expect(meta.IsStatusConditionTrue(airplane.Status.Conditions, playv1alpha1.ConditionAssembled)).To(BeTrue())
+			g.Expect(meta.IsStatusConditionFalse(airplane.Status.Conditions, playv1alpha1.ConditionConflict)).To(BeTrue())
+		}).WithTimeout(5 * time.Second).Should(Succeed())
+
+		By("creating a second airplane with the same tail number")
+		twinKey := types.NamespacedName{
+			Name:      uuid.New().String()[0:8],
+			Namespace: key.Namespace,
+		}
+		twin := &playv1alpha1.Airplane{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      twinKey.Name,
+				Namespace: twinKey.Namespace,
+			},
+			Spec: playv1alpha1.AirplaneSpec{
+				TailNumber: ucTailNumber,
+			},
+		}
+		Expect(k8sClient.Create(context.TODO(), twin)).To(Succeed())
+
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(context.TODO(), twinKey, twin)).To(Succeed())
+			g.Expect(meta.IsStatusConditionTrue(twin.Status.Conditions, playv1alpha1.ConditionConflict)).To(BeTrue())
+			g.Expect(meta.IsStatusConditionFalse(twin.Status.Conditions, playv1alpha1.ConditionAssembled)).To(BeTrue())
+			g.Expect(meta.IsStatusConditionFalse(twin.Status.Conditions, playv1alpha1.ConditionReady)).To(BeTrue())
+			g.Expect(twin.Status.Rudder.Name).To(BeEmpty())
+		}).Should(Succeed())
+
+		By("checking the conflict was announced")
+		// The registration is checked before any of the parts.
+		Eventually(func(g Gomega) {
+			g.Expect(eventReasons(g, twin)).To(ContainElement("RegistrationConflict"))
+		}).Should(Succeed())
+
+		Expect(k8sClient.Delete(context.TODO(), twin)).To(Succeed())
+	})
 })
+
+var _ = Describe("Airplane unit tests for registration", func() {
+
+	var (
+		ucTailNumber string
+		rkey         types.NamespacedName
+	)
+
+	newAirplane := func(namespace string) *playv1alpha1.Airplane {
+		airplane := &playv1alpha1.Airplane{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      uuid.New().String()[0:8],
+				Namespace: namespace,
+			},
+			Spec: playv1alpha1.AirplaneSpec{
+				TailNumber: ucTailNumber,
+			},
+		}
+		Expect(k8sClient.Create(context.TODO(), airplane)).To(Succeed())
+		return airplane
+	}
+
+	holder := func() types.UID {
+		registration := &playv1alpha1.Registration{}
+		if err := k8sClient.Get(context.TODO(), rkey, registration); err != nil {
+			return ""
+		}
+		return registration.Spec.AirplaneRef.UID
+	}
+
+	BeforeEach(func() {
+		ucTailNumber = newTailNumber()
+		rkey = types.NamespacedName{Name: strings.ToLower(ucTailNumber)}
+	})
+
+	It("Holds a tail number for one airplane across the cluster", func() {
+		first := newAirplane(corev1.NamespaceDefault)
+		Eventually(holder).Should(Equal(first.GetUID()))
+
+		By("creating an airplane with the same tail number in another namespace")
+		namespace := &corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "registry-" + uuid.New().String()[0:8],
+			},
+		}
+		Expect(k8sClient.Create(context.TODO(), namespace)).To(Succeed())
+		second := newAirplane(namespace.Name)
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(context.TODO(), client.ObjectKeyFromObject(second), second)).To(Succeed())
+			cond := meta.FindStatusCondition(second.Status.Conditions, playv1alpha1.ConditionConflict)
+			g.Expect(cond).NotTo(BeNil())
+			g.Expect(cond.Status).To(Equal(metav1.ConditionTrue))
+			g.Expect(cond.Reason).To(Equal("RegistrationConflict"))
+			g.Expect(second.Status.Rudder.Name).To(BeEmpty())
+		}).Should(Succeed())
+
+		By("deleting the first airplane")
+		Expect(k8sClient.Delete(context.TODO(), first)).To(Succeed())
+		Eventually(func() error {
+			return k8sClient.Get(context.TODO(), client.ObjectKeyFromObject(first), first)
+		}).Should(Satisfy(apierrors.IsNotFound))
+
+		By("checking the second airplane took the registration")
+		Eventually(holder).Should(Equal(second.GetUID()))
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(context.TODO(), client.ObjectKeyFromObject(second), second)).To(Succeed())
+			g.Expect(meta.IsStatusConditionFalse(second.Status.Conditions, playv1alpha1.ConditionConflict)).To(BeTrue())
+			g.Expect(meta.IsStatusConditionTrue(second.Status.Conditions, playv1alpha1.ConditionAssembled)).To(BeTrue())
+		}).WithTimeout(5 * time.Second).Should(Succeed())
+
+		By("deleting the second airplane")
+		Expect(k8sClient.Delete(context.TODO(), second)).To(Succeed())
+		Eventually(func() error {
+			return k8sClient.Get(context.TODO(), rkey, &playv1alpha1.Registration{})
+		}).Should(Satisfy(apierrors.IsNotFound))
+	})
+
+	It("Takes over a registration whose airplane is gone", func() {
+		registration := &playv1alpha1.Registration{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: rkey.Name,
+			},
+			Spec: playv1alpha1.RegistrationSpec{
+				TailNumber: ucTailNumber,
+				AirplaneRef: corev1.ObjectReference{
+					Namespace: corev1.NamespaceDefault,
+					Name:      "scrapped",
+					UID:       types.UID(uuid.New().String()),
+				},
+			},
+		}
+		Expect(k8sClient.Create(context.TODO(), registration)).To(Succeed())
+
+		airplane := newAirplane(corev1.NamespaceDefault)
+		Eventually(holder).Should(Equal(airplane.GetUID()))
+
+		Expect(k8sClient.Delete(context.TODO(), airplane)).To(Succeed())
+		Eventually(func() error {
+			return k8sClient.Get(context.TODO(), rkey, &playv1alpha1.Registration{})
+		}).Should(Satisfy(apierrors.IsNotFound))
+	})
+})
+
+var _ = Describe("Airplane unit tests for teardown", func() {
+
+	var (
+		key      types.NamespacedName
+		ckey     types.NamespacedName
+		airplane *playv1alpha1.Airplane
+	)
+
+	BeforeEach(func() {
+		key = types.NamespacedName{
+			Name:      uuid.New().String()[0:8],
+			Namespace: corev1.NamespaceDefault,
+		}
+		tailNumber := newTailNumber()
+		airplane = &playv1alpha1.Airplane{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      key.Name,
+				Namespace: key.Namespace,
+			},
+			Spec: playv1alpha1.AirplaneSpec{
+				TailNumber: tailNumber,
+			},
+		}
+		Expect(k8sClient.Create(context.TODO(), airplane)).To(Succeed())
+		ckey = types.NamespacedName{
+			Name:      strings.ToLower(tailNumber),
+			Namespace: key.Namespace,
+		}
+
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(context.TODO(), key, airplane)).To(Succeed())
+			g.Expect(meta.IsStatusConditionTrue(airplane.Status.Conditions, playv1alpha1.ConditionReady)).To(BeTrue())
+		}).WithTimeout(5 * time.Second).Should(Succeed())
+	})
+
+	setFlaps := func(position, extensionRate int32) {
+		flaps := &playv1alpha1.Flaps{}
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(context.TODO(), ckey, flaps)).To(Succeed())
+			flaps.Spec.Position = position
+			flaps.Spec.ExtensionRate = extensionRate
+			g.Expect(k8sClient.Update(context.TODO(), flaps)).To(Succeed())
+		}).Should(Succeed())
+	}
+
+	It("Parks the controls before it deletes the parts", func() {
+		By("pushing the pedals")
+		pedals := &playv1alpha1.Pedals{}
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(context.TODO(), ckey, pedals)).To(Succeed())
+			pedals.Spec.Pressed = "left"
+			g.Expect(k8sClient.Update(context.TODO(), pedals)).To(Succeed())
+		}).Should(Succeed())
+		rudder := &playv1alpha1.Rudder{}
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(context.TODO(), ckey, rudder)).To(Succeed())
+			g.Expect(rudder.Status.Deflection).ToNot(BeZero())
+		}).Should(Succeed())
+
+		By("deleting the airplane")
+		Expect(k8sClient.Delete(context.TODO(), airplane)).To(Succeed())
+		Eventually(func() error {
+			return k8sClient.Get(context.TODO(), key, airplane)
+		}).WithTimeout(3 * time.Second).Should(Satisfy(apierrors.IsNotFound))
+		Expect(k8sClient.Get(context.TODO(), ckey, &playv1alpha1.Pedals{})).To(Satisfy(apierrors.IsNotFound))
+		Expect(k8sClient.Get(context.TODO(), ckey, &playv1alpha1.Rudder{})).To(Satisfy(apierrors.IsNotFound))
+
+		By("checking the final snapshot")
+		snapshot := &corev1.ConfigMap{}
+		Expect(k8sClient.Get(context.TODO(), types.NamespacedName{Name: key.Name + "-final", Namespace: key.Namespace}, snapshot)).To(Succeed())
+		Expect(snapshot.Data).To(HaveKey("airplane.json"))
+		Expect(snapshot.Data).To(HaveKeyWithValue("pedals.json", ContainSubstring(`"linkagePosition":"neutral"`)))
+		Expect(snapshot.Data).To(HaveKeyWithValue("rudder.json", ContainSubstring(`"position":"neutral"`)))
+		Expect(k8sClient.Delete(context.TODO(), snapshot)).To(Succeed())
+	})
+
+	It("Reports that it is stuck when a surface won't stow", func() {
+		By("extending the flaps and slowing the flap motor")
+		setFlaps(10, 30)
+		flaps := &playv1alpha1.Flaps{}
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(context.TODO(), ckey, flaps)).To(Succeed())
+			g.Expect(flaps.Status.Position).To(Equal(int32(10)))
+		}).Should(Succeed())
+		setFlaps(10, 1)
+
+		By("deleting the airplane")
+		Expect(k8sClient.Delete(context.TODO(), airplane)).To(Succeed())
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(context.TODO(), key, airplane)).To(Succeed())
+			cond := meta.FindStatusCondition(airplane.Status.Conditions, playv1alpha1.ConditionStuck)
+			g.Expect(cond).NotTo(BeNil())
+			g.Expect(cond.Status).To(Equal(metav1.ConditionTrue))
+			g.Expect(cond.Message).To(ContainSubstring("flaps"))
+		}).WithTimeout(5 * time.Second).Should(Succeed())
+		Expect(k8sClient.Get(context.TODO(), ckey, &playv1alpha1.Rudder{})).To(Succeed())
+
+		By("speeding the flap motor back up")
+		setFlaps(0, 30)
+		Eventually(func() error {
+			return k8sClient.Get(context.TODO(), key, airplane)
+		}).WithTimeout(3 * time.Second).Should(Satisfy(apierrors.IsNotFound))
+		Expect(k8sClient.Get(context.TODO(), ckey, &playv1alpha1.Flaps{})).To(Satisfy(apierrors.IsNotFound))
+		Expect(k8sClient.Delete(context.TODO(), &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: key.Name + "-final", Namespace: key.Namespace},
+		})).To(Succeed())
+	})
+
+	It("Lets go of an airplane that has the old finalizer", func() {
+		By("putting back the finalizer from before the rename")
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(context.TODO(), key, airplane)).To(Succeed())
+			airplane.Finalizers = []string{registrationFinalizer}
+			g.Expect(k8sClient.Update(context.TODO(), airplane)).To(Succeed())
+		}).Should(Succeed())
+
+		By("deleting the airplane")
+		Expect(k8sClient.Delete(context.TODO(), airplane)).To(Succeed())
+		Eventually(func() error {
+			return k8sClient.Get(context.TODO(), key, airplane)
+		}).WithTimeout(3 * time.Second).Should(Satisfy(apierrors.IsNotFound))
+		Expect(k8sClient.Get(context.TODO(), ckey, &playv1alpha1.Rudder{})).To(Satisfy(apierrors.IsNotFound))
+		Expect(k8sClient.Delete(context.TODO(), &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: key.Name + "-final", Namespace: key.Namespace},
+		})).To(Succeed())
+	})
+})
+
+var _ = Describe("Airplane unit tests with a pre-created part", func() {
+
+	It("Does not adopt a rudder it did not create", func() {
+		ucTailNumber := newTailNumber()
+		ckey := types.NamespacedName{
+			Name:      strings.ToLower(ucTailNumber),
+			Namespace: corev1.NamespaceDefault,
+		}
+		rudder := &playv1alpha1.Rudder{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      ckey.Name,
+				Namespace: ckey.Namespace,
+			},
+		}
+		Expect(k8sClient.Create(context.TODO(), rudder)).To(Succeed())
+
+		key := types.NamespacedName{
+			Name:      uuid.New().String()[0:8],
+			Namespace: corev1.NamespaceDefault,
+		}
+		airplane := &playv1alpha1.Airplane{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      key.Name,
+				Namespace: key.Namespace,
+			},
+			Spec: playv1alpha1.AirplaneSpec{
+				TailNumber: ucTailNumber,
+			},
+		}
+		Expect(k8sClient.Create(context.TODO(), airplane)).To(Succeed())
+
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(context.TODO(), key, airplane)).To(Succeed())
+			cond := meta.FindStatusCondition(airplane.Status.Conditions, playv1alpha1.ConditionConflict)
+			g.Expect(cond).NotTo(BeNil())
+			g.Expect(cond.Status).To(Equal(metav1.ConditionTrue))
+			g.Expect(cond.Message).To(ContainSubstring("Rudder"))
+		}).Should(Succeed())
+
+		Expect(k8sClient.Get(context.TODO(), ckey, rudder)).To(Succeed())
+		Expect(rudder.GetOwnerReferences()).To(BeEmpty())
+
+		Expect(k8sClient.Delete(context.TODO(), airplane)).To(Succeed())
+		Expect(k8sClient.Delete(context.TODO(), rudder)).To(Succeed())
+	})
+})
+
+var _ = Describe("Airplane unit tests with an aircraft type", func() {
+
+	var (
+		key          types.NamespacedName
+		ckey         types.NamespacedName
+		airplane     *playv1alpha1.Airplane
+		aircraftType *playv1alpha1.AircraftType
+	)
+
+	BeforeEach(func() {
+		key = types.NamespacedName{
+			Name:      uuid.New().String()[0:8],
+			Namespace: corev1.NamespaceDefault,
+		}
+
+		// A fixed-gear trainer, with a rudder that doesn't go as far
+		// left as the default one, and is pinned on the right.
+		minDeflection, maxDeflection := int32(-20), int32(0)
+		aircraftType = &playv1alpha1.AircraftType{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "type-" + uuid.New().String()[0:8],
+			},
+			Spec: playv1alpha1.AircraftTypeSpec{
+				Description: "Trainer",
+				Rudder: playv1alpha1.SurfaceParameters{
+					MinDeflection: &minDeflection,
+					MaxDeflection: &maxDeflection,
+				},
+				Flaps: &playv1alpha1.FlapsParameters{
+					MaxPosition: 20,
+				},
+			},
+		}
+
+		tailNumber := newTailNumber()
+		airplane = &playv1alpha1.Airplane{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      key.Name,
+				Namespace: key.Namespace,
+			},
+			Spec: playv1alpha1.AirplaneSpec{
+				TailNumber: tailNumber,
+				TypeRef:    &corev1.LocalObjectReference{Name: aircraftType.Name},
+			},
+		}
+		ckey = types.NamespacedName{
+			Name:      strings.ToLower(tailNumber),
+			Namespace: key.Namespace,
+		}
+	})
+
+	AfterEach(func() {
+		Expect(k8sClient.Delete(context.TODO(), airplane)).To(Succeed())
+		Expect(k8sClient.Delete(context.TODO(), aircraftType)).To(Succeed())
+
+		// Test env limitation: Wait until the cached object is gone.
+		expected := &playv1alpha1.Airplane{}
+		Eventually(func() error {
+			return k8sClient.Get(context.TODO(), key, expected)
+		}).ShouldNot(Succeed())
+	})
+
+	It("Builds the parts that the type describes", func() {
+		Expect(k8sClient.Create(context.TODO(), aircraftType)).To(Succeed())
+		Expect(k8sClient.Create(context.TODO(), airplane)).To(Succeed())
+
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(context.TODO(), key, airplane)).To(Succeed())
+			g.Expect(airplane.Status.Flaps.Name).To(Equal(ckey.Name))
+		}).Should(Succeed())
+
+		rudder := &playv1alpha1.Rudder{}
+		Expect(k8sClient.Get(context.TODO(), ckey, rudder)).To(Succeed())
+		minDeflection, maxDeflection := rudder.Spec.Limits()
+		Expect(minDeflection).To(Equal(int32(-20)))
+		Expect(maxDeflection).To(BeZero(), "a limit of zero is kept")
+		Expect(rudder.Spec.SlewRate).To(Equal(int32(60)), "default slew rate")
+
+		flaps := &playv1alpha1.Flaps{}
+		Expect(k8sClient.Get(context.TODO(), ckey, flaps)).To(Succeed())
+		Expect(flaps.Spec.MaxPosition).To(Equal(int32(20)))
+
+		By("checking the fixed gear has no lever")
+		Consistently(func(g Gomega) {
+			g.Expect(k8sClient.Get(context.TODO(), key, airplane)).To(Succeed())
+			g.Expect(airplane.Status.GearLever.Name).To(BeEmpty())
+			g.Expect(airplane.Status.LandingGear.Name).To(BeEmpty())
+		}).Should(Succeed())
+
+		By("checking the controls it has are linked")
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(context.TODO(), key, airplane)).To(Succeed())
+			g.Expect(meta.IsStatusConditionTrue(airplane.Status.Conditions, playv1alpha1.ConditionControlsLinked)).To(BeTrue())
+		}).Should(Succeed())
+	})
+
+	It("Rejects a type with impossible aerodynamics", func() {
+		for _, aerodynamics := range []playv1alpha1.AerodynamicsParameters{
+			{Mass: -1100},
+			{WingArea: -16},
+			{StallAngle: 90},
+			{ParasiteDrag: 2},
+		} {
+			bad := aircraftType.DeepCopy()
+			bad.Name = "bad-" + uuid.New().String()[0:8]
+			bad.Spec.Aerodynamics = aerodynamics
+			err := k8sClient.Create(context.TODO(), bad)
+			Expect(apierrors.IsInvalid(err)).To(BeTrue(), "aerodynamics %+v", aerodynamics)
+		}
+
+		Expect(k8sClient.Create(context.TODO(), aircraftType)).To(Succeed())
+		Expect(k8sClient.Create(context.TODO(), airplane)).To(Succeed())
+	})
+
+	It("Waits for the type before building parts", func() {
+		Expect(k8sClient.Create(context.TODO(), airplane)).To(Succeed())
+
+		Eventually(func() error {
+			return k8sClient.Get(context.TODO(), key, airplane)
+		}).Should(Succeed())
+		Consistently(func(g Gomega) {
+			g.Expect(k8sClient.Get(context.TODO(), key, airplane)).To(Succeed())
+			g.Expect(airplane.Status.Rudder.Name).To(BeEmpty())
+		}).Should(Succeed())
+		condition := meta.FindStatusCondition(airplane.Status.Conditions, playv1alpha1.ConditionAssembled)
+		Expect(condition).ToNot(BeNil())
+		Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+		Expect(condition.Reason).To(Equal("TypeNotFound"))
+
+		Expect(k8sClient.Create(context.TODO(), aircraftType)).To(Succeed())
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(context.TODO(), key, airplane)).To(Succeed())
+			g.Expect(airplane.Status.Rudder.Name).To(Equal(ckey.Name))
+		}).Should(Succeed())
+	})
+})
+
+// eventReasons returns the reasons of the events recorded on an object.
+func eventReasons(g Gomega, obj client.Object) []string {
+	events := &corev1.EventList{}
+	g.Expect(k8sClient.List(context.TODO(), events, client.InNamespace(obj.GetNamespace()))).To(Succeed())
+	reasons := []string{}
+	for _, event := range events.Items {
+		if event.InvolvedObject.UID == obj.GetUID() {
+			reasons = append(reasons, event.Reason)
+		}
+	}
+	return reasons
+}
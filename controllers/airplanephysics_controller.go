@@ -0,0 +1,301 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"math"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	playv1alpha1 "github.com/roehrich-hpe/airplane-sim/api/v1alpha1"
+	"github.com/roehrich-hpe/airplane-sim/pkg/flightdynamics"
+)
+
+const (
+	// physicsTick is how often the flight dynamics model is advanced and
+	// the results are published.
+	physicsTick = 500 * time.Millisecond
+
+	// maxPhysicsCatchUp limits how much time the model will integrate in
+	// one pass, so an airplane that hasn't been looked at in a while
+	// doesn't spend a long time catching up.
+	maxPhysicsCatchUp = 10 * physicsTick
+
+	metersPerFoot = 0.3048
+	metersPerKnot = 1852.0 / 3600.0
+	feetPerMinute = metersPerFoot / 60
+)
+
+// AirplanePhysicsReconciler flies an Airplane object
+type AirplanePhysicsReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+}
+
+//+kubebuilder:rbac:groups=play.github.com,resources=airplanes,verbs=get;list;watch
+//+kubebuilder:rbac:groups=play.github.com,resources=airplanes/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=play.github.com,resources=rudders,verbs=get;list;watch
+//+kubebuilder:rbac:groups=play.github.com,resources=ailerons,verbs=get;list;watch
+//+kubebuilder:rbac:groups=play.github.com,resources=elevators,verbs=get;list;watch
+//+kubebuilder:rbac:groups=play.github.com,resources=flaps,verbs=get;list;watch
+//+kubebuilder:rbac:groups=play.github.com,resources=landinggears,verbs=get;list;watch
+//+kubebuilder:rbac:groups=play.github.com,resources=aircrafttypes,verbs=get;list;watch
+
+// Reconcile advances the flight dynamics model of a simulated airplane on a
+// fixed tick of simulation time, using the current positions of its control surfaces, and
+// publishes the result in the airplane's status.
+//
+// For more details, check Reconcile and its Result here:
+// - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.12.2/pkg/reconcile
+func (r *AirplanePhysicsReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := log.FromContext(ctx).WithName("airplanephysics")
+
+	airplane := &playv1alpha1.Airplane{}
+	if err := r.Get(ctx, req.NamespacedName, airplane); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	if !airplane.Spec.Simulated {
+		return ctrl.Result{}, nil
+	}
+
+	clock, err := readClock(ctx, r.Client, req.Namespace)
+	if err != nil {
+		log.Error(err, "Unable to read simulation clock")
+		return ctrl.Result{}, err
+	}
+
+	now := clock.now
+	stepped := now
+	status := &airplane.Status
+	state := flightdynamics.State{OnGround: true}
+	if status.Flight == nil || status.Flight.LastStepped == nil {
+		// Parked at the start of the runway.
+		log.Info("Starting flight dynamics")
+	} else {
+		elapsed := now.Sub(status.Flight.LastStepped.Time)
+		if elapsed >= 0 && elapsed < physicsTick {
+			// Woken early, probably by our own status update.
+			return ctrl.Result{RequeueAfter: clock.wallTime(physicsTick - elapsed)}, nil
+		}
+		if elapsed < 0 {
+			// The clock went backwards, which happens when a
+			// SimClock is removed.  Carry on from here.
+			elapsed = 0
+		}
+		if elapsed > maxPhysicsCatchUp {
+			elapsed = maxPhysicsCatchUp
+		}
+
+		controls, err := r.controls(ctx, airplane)
+		if err != nil {
+			log.Error(err, "Unable to read controls")
+			return ctrl.Result{}, err
+		}
+
+		aircraft, err := r.aircraft(ctx, airplane)
+		if err != nil {
+			log.Error(err, "Unable to get aircraft type")
+			return ctrl.Result{}, err
+		}
+
+		wasOnGround := status.WeightOnWheels == nil || *status.WeightOnWheels
+		state = flightState(status.Flight, wasOnGround)
+		state = flightdynamics.Simulate(aircraft, state, controls, elapsed)
+		stepped = steppedTo(now, elapsed)
+		if state.OnGround != wasOnGround {
+			log.Info("Squat switch changed", "weightOnWheels", state.OnGround, "airspeed", state.IndicatedAirspeed()/metersPerKnot)
+		}
+	}
+
+	setFlightStatus(status, state, stepped)
+	if err := r.Status().Update(ctx, airplane); err != nil {
+		if apierrors.IsConflict(err) {
+			log.Info("Conflict while setting flight status")
+			return ctrl.Result{Requeue: true}, nil
+		}
+		log.Error(err, "Error while setting flight status")
+		return ctrl.Result{}, err
+	}
+
+	return ctrl.Result{RequeueAfter: clock.wallTime(physicsTick)}, nil
+}
+
+// aircraft returns the flight dynamics model for the airplane's type.  The
+// coefficients that the type leaves out keep the model's defaults, as does an
+// airplane whose type hasn't been created.
+func (r *AirplanePhysicsReconciler) aircraft(ctx context.Context, airplane *playv1alpha1.Airplane) (flightdynamics.Aircraft, error) {
+	aircraft := flightdynamics.DefaultAircraft()
+
+	aircraftType, err := getAircraftType(ctx, r.Client, airplane)
+	if err != nil {
+		return aircraft, client.IgnoreNotFound(err)
+	}
+
+	override := func(value *float64, param float64) {
+		if param != 0 {
+			*value = param
+		}
+	}
+	params := &aircraftType.Aerodynamics
+	override(&aircraft.Mass, params.Mass)
+	override(&aircraft.WingArea, params.WingArea)
+	override(&aircraft.MaxThrust, params.MaxThrust)
+	override(&aircraft.PropellerSpeed, params.PropellerSpeed)
+	override(&aircraft.LiftCoefficient, params.LiftCoefficient)
+	override(&aircraft.LiftSlope, params.LiftSlope)
+	override(&aircraft.StallAngle, params.StallAngle)
+	override(&aircraft.ParasiteDrag, params.ParasiteDrag)
+	override(&aircraft.InducedDrag, params.InducedDrag)
+	override(&aircraft.GearDrag, params.GearDrag)
+	override(&aircraft.FlapLift, params.FlapLift)
+	override(&aircraft.FlapDrag, params.FlapDrag)
+	override(&aircraft.RollPower, params.RollPower)
+	if aircraftType.Flaps != nil && aircraftType.Flaps.MaxPosition > 0 {
+		aircraft.MaxFlaps = float64(aircraftType.Flaps.MaxPosition)
+	}
+
+	return aircraft, nil
+}
+
+// controls gathers the positions of the airplane's control surfaces.  A part
+// that doesn't exist yet is treated as being at neutral.
+func (r *AirplanePhysicsReconciler) controls(ctx context.Context, airplane *playv1alpha1.Airplane) (flightdynamics.Controls, error) {
+	controls := flightdynamics.Controls{
+		Throttle: float64(airplane.Spec.Throttle) / 100,
+		GearDown: true,
+	}
+	status := &airplane.Status
+
+	rudder := &playv1alpha1.Rudder{}
+	if found, err := r.getPart(ctx, airplane, status.Rudder.Name, rudder); err != nil {
+		return controls, err
+	} else if found {
+		controls.Rudder = float64(rudder.Status.Deflection)
+	}
+
+	aileron := &playv1alpha1.Aileron{}
+	if found, err := r.getPart(ctx, airplane, status.Aileron.Name, aileron); err != nil {
+		return controls, err
+	} else if found {
+		controls.LeftAileron = float64(aileron.Status.Left)
+		controls.RightAileron = float64(aileron.Status.Right)
+	}
+
+	elevator := &playv1alpha1.Elevator{}
+	if found, err := r.getPart(ctx, airplane, status.Elevator.Name, elevator); err != nil {
+		return controls, err
+	} else if found {
+		controls.Elevator = float64(elevator.Status.Deflection)
+	}
+
+	flaps := &playv1alpha1.Flaps{}
+	if found, err := r.getPart(ctx, airplane, status.Flaps.Name, flaps); err != nil {
+		return controls, err
+	} else if found {
+		controls.Flaps = float64(flaps.Status.Position)
+	}
+
+	landingGear := &playv1alpha1.LandingGear{}
+	if found, err := r.getPart(ctx, airplane, status.LandingGear.Name, landingGear); err != nil {
+		return controls, err
+	} else if found {
+		controls.GearDown = landingGear.Status.State != playv1alpha1.GearUpLocked
+	}
+
+	return controls, nil
+}
+
+// getPart fetches one of the airplane's parts by name.  It reports false if
+// the part hasn't been created yet.
+func (r *AirplanePhysicsReconciler) getPart(ctx context.Context, airplane *playv1alpha1.Airplane, name string, part client.Object) (bool, error) {
+	if len(name) == 0 {
+		return false, nil
+	}
+	key := types.NamespacedName{Name: name, Namespace: airplane.GetNamespace()}
+	if err := r.Get(ctx, key, part); err != nil {
+		if apierrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// flightState converts the published flight status back into the model's
+// units.
+func flightState(flight *playv1alpha1.FlightStatus, onGround bool) flightdynamics.State {
+	return flightdynamics.State{
+		North:         flight.North,
+		East:          flight.East,
+		Altitude:      flight.Altitude * metersPerFoot,
+		Heading:       flight.Heading,
+		Pitch:         flight.Pitch,
+		Roll:          flight.Roll,
+		AngleOfAttack: flight.AngleOfAttack,
+		FlightPath:    flight.FlightPath,
+		Airspeed:      flight.TrueAirspeed * metersPerKnot,
+		OnGround:      onGround,
+	}
+}
+
+// steppedTo returns the simulation time that the model has been brought up
+// to after simulating the elapsed time.  Simulate only takes whole steps, so
+// this falls short of now by the remainder, which the next tick picks up.
+func steppedTo(now time.Time, elapsed time.Duration) time.Time {
+	return now.Add(-(elapsed % flightdynamics.StepSize))
+}
+
+// setFlightStatus publishes the model's state in the airplane's status, as
+// of the simulation time the model has been stepped to.
+func setFlightStatus(status *playv1alpha1.AirplaneStatus, state flightdynamics.State, steppedTo time.Time) {
+	stepped := metav1.NewMicroTime(steppedTo)
+	status.Flight = &playv1alpha1.FlightStatus{
+		North:         state.North,
+		East:          state.East,
+		Altitude:      state.Altitude / metersPerFoot,
+		Heading:       state.Heading,
+		Pitch:         state.Pitch,
+		Roll:          state.Roll,
+		AngleOfAttack: state.AngleOfAttack,
+		FlightPath:    state.FlightPath,
+		TrueAirspeed:  state.Airspeed / metersPerKnot,
+		VerticalSpeed: state.VerticalSpeed() / feetPerMinute,
+		LastStepped:   &stepped,
+	}
+	status.Airspeed = int32(math.Round(state.IndicatedAirspeed() / metersPerKnot))
+	onGround := state.OnGround
+	status.WeightOnWheels = &onGround
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *AirplanePhysicsReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("airplanephysics").
+		For(&playv1alpha1.Airplane{}).
+		Watches(&source.Kind{Type: &playv1alpha1.SimClock{}}, handler.EnqueueRequestsFromMapFunc(clockWatcher(mgr.GetClient(), &playv1alpha1.AirplaneList{}))).
+		Complete(r)
+}
@@ -0,0 +1,150 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	playv1alpha1 "github.com/roehrich-hpe/airplane-sim/api/v1alpha1"
+)
+
+var _ = Describe("AirplanePhysics Unit Tests", func() {
+
+	var (
+		key      types.NamespacedName
+		airplane *playv1alpha1.Airplane
+	)
+
+	BeforeEach(func() {
+		key = types.NamespacedName{
+			Name:      uuid.New().String()[0:8],
+			Namespace: corev1.NamespaceDefault,
+		}
+
+		airplane = &playv1alpha1.Airplane{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      key.Name,
+				Namespace: key.Namespace,
+			},
+			Spec: playv1alpha1.AirplaneSpec{
+				TailNumber: newTailNumber(),
+				Simulated:  true,
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		Expect(k8sClient.Create(context.TODO(), airplane)).To(Succeed())
+	})
+
+	AfterEach(func() {
+		Expect(k8sClient.Delete(context.TODO(), airplane)).To(Succeed())
+
+		// Test env limitation: Wait until the cached object is gone.
+		expected := &playv1alpha1.Airplane{}
+		Eventually(func() error {
+			return k8sClient.Get(context.TODO(), key, expected)
+		}).ShouldNot(Succeed())
+	})
+
+	It("Parks a new airplane on the runway", func() {
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(context.TODO(), key, airplane)).To(Succeed())
+			g.Expect(airplane.Status.Flight).ToNot(BeNil())
+			g.Expect(airplane.Status.WeightOnWheels).To(HaveValue(BeTrue()))
+		}).Should(Succeed())
+
+		Consistently(func(g Gomega) {
+			g.Expect(k8sClient.Get(context.TODO(), key, airplane)).To(Succeed())
+			g.Expect(airplane.Status.Airspeed).To(BeZero())
+			g.Expect(airplane.Status.Flight.Altitude).To(BeZero())
+		}).Should(Succeed())
+	})
+
+	It("Accelerates down the runway with the throttle open", func() {
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(context.TODO(), key, airplane)).To(Succeed())
+			airplane.Spec.Throttle = 100
+			g.Expect(k8sClient.Update(context.TODO(), airplane)).To(Succeed())
+		}).Should(Succeed())
+
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(context.TODO(), key, airplane)).To(Succeed())
+			g.Expect(airplane.Status.Airspeed).To(BeNumerically(">=", 5))
+			g.Expect(airplane.Status.Flight.North).To(BeNumerically(">", 0))
+			g.Expect(airplane.Status.WeightOnWheels).To(HaveValue(BeTrue()))
+		}).WithTimeout(5 * time.Second).Should(Succeed())
+	})
+
+	It("Keeps flying once airborne", func() {
+		By("putting the airplane in the air")
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(context.TODO(), key, airplane)).To(Succeed())
+			g.Expect(airplane.Status.Flight).ToNot(BeNil())
+			airplane.Status.Flight.Altitude = 3000
+			airplane.Status.Flight.TrueAirspeed = 100
+			weightOnWheels := false
+			airplane.Status.WeightOnWheels = &weightOnWheels
+			g.Expect(k8sClient.Status().Update(context.TODO(), airplane)).To(Succeed())
+		}).Should(Succeed())
+
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(context.TODO(), key, airplane)).To(Succeed())
+			g.Expect(airplane.Status.WeightOnWheels).To(HaveValue(BeFalse()))
+			g.Expect(airplane.Status.Flight.Altitude).ToNot(Equal(3000.0))
+			g.Expect(airplane.Status.Flight.Altitude).To(BeNumerically(">", 2000))
+			g.Expect(airplane.Status.Airspeed).To(BeNumerically(">", 50))
+		}).Should(Succeed())
+	})
+
+	It("Carries the part of a step it didn't simulate over to the next tick", func() {
+		now := time.Now()
+		Expect(steppedTo(now, 510*time.Millisecond)).To(Equal(now.Add(-10 * time.Millisecond)))
+		Expect(steppedTo(now, 500*time.Millisecond)).To(Equal(now))
+		Expect(steppedTo(now, 0)).To(Equal(now))
+
+		// Wait for the airplane, so there's one to clean up.
+		Eventually(func() error {
+			return k8sClient.Get(context.TODO(), key, airplane)
+		}).Should(Succeed())
+	})
+
+	When("the airplane is not simulated", func() {
+		BeforeEach(func() {
+			airplane.Spec.Simulated = false
+		})
+
+		It("Leaves the status alone", func() {
+			Eventually(func() error {
+				return k8sClient.Get(context.TODO(), key, airplane)
+			}).Should(Succeed())
+			Consistently(func(g Gomega) {
+				g.Expect(k8sClient.Get(context.TODO(), key, airplane)).To(Succeed())
+				g.Expect(airplane.Status.Flight).To(BeNil())
+			}).Should(Succeed())
+		})
+	})
+})
@@ -0,0 +1,96 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	playv1alpha1 "github.com/roehrich-hpe/airplane-sim/api/v1alpha1"
+)
+
+// ElevatorReconciler reconciles a Elevator object
+type ElevatorReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+}
+
+//+kubebuilder:rbac:groups=play.github.com,resources=elevators,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=play.github.com,resources=elevators/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=play.github.com,resources=elevators/finalizers,verbs=update
+
+// Reconcile moves the elevator toward its commanded deflection plus trim, at
+// the actuator's slew rate.
+//
+// For more details, check Reconcile and its Result here:
+// - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.12.2/pkg/reconcile
+func (r *ElevatorReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := log.FromContext(ctx).WithName("elevator")
+
+	elevator := &playv1alpha1.Elevator{}
+	if err := r.Get(ctx, req.NamespacedName, elevator); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	clock, err := readClock(ctx, r.Client, req.Namespace)
+	if err != nil {
+		log.Error(err, "Unable to read simulation clock")
+		return ctrl.Result{}, err
+	}
+
+	spec := &elevator.Spec
+	commanded := clamp(spec.Deflection, spec.MinDeflection, spec.MaxDeflection)
+	trim := clamp(spec.Trim, -spec.MaxTrim, spec.MaxTrim)
+	target := clamp(commanded+trim, spec.MinDeflection, spec.MaxDeflection)
+	deflection, lastMoved, wait := slew(elevator.Status.Deflection, target, spec.SlewRate, elevator.Status.LastMoved, clock.now)
+
+	status := &elevator.Status
+	if status.Commanded != commanded || status.Trim != trim || status.Deflection != deflection || !lastMoved.Equal(status.LastMoved) {
+		if status.Deflection != deflection {
+			log.Info("Moving elevator", "deflection", deflection, "target", target)
+		}
+		status.Commanded = commanded
+		status.Trim = trim
+		status.Deflection = deflection
+		status.LastMoved = lastMoved
+		if err := r.Status().Update(ctx, elevator); err != nil {
+			if apierrors.IsConflict(err) {
+				log.Info("Conflict while setting position")
+				return ctrl.Result{Requeue: true}, nil
+			}
+			log.Error(err, "Error while setting position")
+			return ctrl.Result{}, err
+		}
+	}
+
+	return ctrl.Result{RequeueAfter: clock.wallTime(wait)}, nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *ElevatorReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&playv1alpha1.Elevator{}).
+		Watches(&source.Kind{Type: &playv1alpha1.SimClock{}}, handler.EnqueueRequestsFromMapFunc(clockWatcher(mgr.GetClient(), &playv1alpha1.ElevatorList{}))).
+		Complete(r)
+}
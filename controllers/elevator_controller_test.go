@@ -0,0 +1,83 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	playv1alpha1 "github.com/roehrich-hpe/airplane-sim/api/v1alpha1"
+)
+
+var _ = Describe("Elevator Unit Tests", func() {
+
+	var (
+		key      types.NamespacedName
+		elevator *playv1alpha1.Elevator
+	)
+
+	BeforeEach(func() {
+		key = types.NamespacedName{
+			Name:      "elevator-" + uuid.New().String()[0:8],
+			Namespace: corev1.NamespaceDefault,
+		}
+
+		elevator = &playv1alpha1.Elevator{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      key.Name,
+				Namespace: key.Namespace,
+			},
+		}
+
+		Expect(k8sClient.Create(context.TODO(), elevator)).To(Succeed())
+	})
+
+	AfterEach(func() {
+		Expect(k8sClient.Delete(context.TODO(), elevator)).To(Succeed())
+
+		// Test env limitation: Wait until the cached object is gone.
+		expected := &playv1alpha1.Elevator{}
+		Eventually(func() error {
+			return k8sClient.Get(context.TODO(), key, expected)
+		}).ShouldNot(Succeed())
+	})
+
+	It("Holds trim and the effective deflection within their limits", func() {
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(context.TODO(), key, elevator)).To(Succeed())
+			elevator.Spec.Deflection = elevator.Spec.MaxDeflection
+			elevator.Spec.Trim = 90
+			g.Expect(k8sClient.Update(context.TODO(), elevator)).To(Succeed())
+		}).Should(Succeed())
+
+		expected := &playv1alpha1.Elevator{}
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(context.TODO(), key, expected)).To(Succeed())
+			g.Expect(expected.Status.Trim).To(Equal(expected.Spec.MaxTrim))
+			g.Expect(expected.Status.Commanded).To(Equal(expected.Spec.MaxDeflection))
+			g.Expect(expected.Status.Deflection).To(Equal(expected.Spec.MaxDeflection))
+		}).WithTimeout(3 * time.Second).Should(Succeed())
+	})
+})
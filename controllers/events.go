@@ -0,0 +1,54 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"reflect"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	playv1alpha1 "github.com/roehrich-hpe/airplane-sim/api/v1alpha1"
+)
+
+// partAirplane returns the airplane that a part belongs to, or nil if the
+// part doesn't belong to an airplane.
+func partAirplane(ctx context.Context, c client.Reader, part client.Object) *playv1alpha1.Airplane {
+	owner := metav1.GetControllerOf(part)
+	if owner == nil || owner.Kind != reflect.TypeOf(playv1alpha1.Airplane{}).Name() {
+		return nil
+	}
+	airplane := &playv1alpha1.Airplane{}
+	key := types.NamespacedName{Name: owner.Name, Namespace: part.GetNamespace()}
+	if err := c.Get(ctx, key, airplane); err != nil || airplane.UID != owner.UID {
+		return nil
+	}
+	return airplane
+}
+
+// recordPartEvent records an event on a part, and again on the airplane the
+// part belongs to, so that describing the airplane shows what its parts
+// have been doing.
+func recordPartEvent(ctx context.Context, c client.Reader, recorder record.EventRecorder, part client.Object, eventtype, reason, messageFmt string, args ...interface{}) {
+	recorder.Eventf(part, eventtype, reason, messageFmt, args...)
+	if airplane := partAirplane(ctx, c, part); airplane != nil {
+		recorder.Eventf(airplane, eventtype, reason, messageFmt, args...)
+	}
+}
@@ -0,0 +1,98 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	playv1alpha1 "github.com/roehrich-hpe/airplane-sim/api/v1alpha1"
+)
+
+// FlapLinkageReconciler reconciles the linkage between a FlapLever and its
+// Flaps
+type FlapLinkageReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+}
+
+//+kubebuilder:rbac:groups=play.github.com,resources=flaplevers,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=play.github.com,resources=flaplevers/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=play.github.com,resources=flaplevers/finalizers,verbs=update
+
+// Reconcile moves the flap linkage to the lever's detent, and asks the flaps
+// to follow the linkage.
+//
+// For more details, check Reconcile and its Result here:
+// - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.12.2/pkg/reconcile
+func (r *FlapLinkageReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := log.FromContext(ctx).WithName("flaplinkage")
+
+	flapLever := &playv1alpha1.FlapLever{}
+	if err := r.Get(ctx, req.NamespacedName, flapLever); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	if flapLever.Status.Detent != flapLever.Spec.Detent {
+		log.Info("Resetting flap linkage")
+		flapLever.Status.Detent = flapLever.Spec.Detent
+		if err := r.Status().Update(ctx, flapLever); err != nil {
+			if apierrors.IsConflict(err) {
+				log.Info("Conflict while setting flap linkage")
+				return ctrl.Result{Requeue: true}, nil
+			}
+			log.Error(err, "Error while setting flap linkage")
+			return ctrl.Result{}, err
+		}
+	}
+
+	// Get the flaps, move them if necessary.
+	flaps := &playv1alpha1.Flaps{}
+	// Flaps and FlapLever have the same name.
+	flapsKey := req.NamespacedName
+	if err := r.Get(ctx, flapsKey, flaps); err != nil {
+		log.Error(err, "Did not find flaps")
+		return ctrl.Result{RequeueAfter: time.Second * 10}, nil
+	}
+
+	if flaps.Spec.Position != flapLever.Status.Detent {
+		flaps.Spec.Position = flapLever.Status.Detent
+		if err := r.Update(ctx, flaps, client.FieldOwner(playv1alpha1.LinkageFieldManager)); err != nil {
+			if apierrors.IsConflict(err) {
+				log.Info("Conflict on flaps")
+				return ctrl.Result{Requeue: true}, nil
+			}
+			log.Error(err, "Error on flaps")
+			return ctrl.Result{}, err
+		}
+		log.Info("flaps have been set")
+	}
+	return ctrl.Result{}, nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *FlapLinkageReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&playv1alpha1.FlapLever{}).
+		Complete(r)
+}
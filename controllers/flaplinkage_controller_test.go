@@ -0,0 +1,118 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	playv1alpha1 "github.com/roehrich-hpe/airplane-sim/api/v1alpha1"
+)
+
+var _ = Describe("FlapLinkage Unit Tests", func() {
+
+	var (
+		key       types.NamespacedName
+		flapLever *playv1alpha1.FlapLever
+
+		flaps *playv1alpha1.Flaps
+	)
+
+	BeforeEach(func() {
+		// FlapLever and flaps have the same value for the name.
+		key = types.NamespacedName{
+			Name:      uuid.New().String()[0:8],
+			Namespace: corev1.NamespaceDefault,
+		}
+
+		flapLever = &playv1alpha1.FlapLever{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      key.Name,
+				Namespace: key.Namespace,
+			},
+		}
+
+		Expect(k8sClient.Create(context.TODO(), flapLever)).To(Succeed())
+
+		flaps = &playv1alpha1.Flaps{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      key.Name,
+				Namespace: key.Namespace,
+			},
+			Spec: playv1alpha1.FlapsSpec{
+				ExtensionRate: 20,
+			},
+		}
+
+		Expect(k8sClient.Create(context.TODO(), flaps)).To(Succeed())
+	})
+
+	AfterEach(func() {
+		Expect(k8sClient.Delete(context.TODO(), flapLever)).To(Succeed())
+		Expect(k8sClient.Delete(context.TODO(), flaps)).To(Succeed())
+
+		// Test env limitation: Wait until the cached object is gone.
+		expected := &playv1alpha1.FlapLever{}
+		Eventually(func() error {
+			return k8sClient.Get(context.TODO(), key, expected)
+		}).ShouldNot(Succeed())
+	})
+
+	It("Only accepts the lever's detents", func() {
+		Expect(k8sClient.Get(context.TODO(), key, flapLever)).To(Succeed())
+		flapLever.Spec.Detent = 15
+		Expect(k8sClient.Update(context.TODO(), flapLever)).ToNot(Succeed())
+	})
+
+	It("Extends the flaps through intermediate positions", func() {
+		By("moving the lever")
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(context.TODO(), key, flapLever)).To(Succeed())
+			flapLever.Spec.Detent = 20
+			g.Expect(k8sClient.Update(context.TODO(), flapLever)).To(Succeed())
+		}).Should(Succeed())
+
+		By("watching the linkage move")
+		expected := &playv1alpha1.FlapLever{}
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(context.TODO(), key, expected)).To(Succeed())
+			g.Expect(expected.Status.Detent).To(Equal(int32(20)))
+		}).Should(Succeed())
+
+		By("watching the flaps pass through an intermediate position")
+		flapsExpected := &playv1alpha1.Flaps{}
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(context.TODO(), key, flapsExpected)).To(Succeed())
+			g.Expect(flapsExpected.Status.Position).To(BeNumerically(">", 0))
+			g.Expect(flapsExpected.Status.Position).To(BeNumerically("<", 20))
+		}).Should(Succeed())
+
+		By("waiting for the flaps to reach the detent")
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(context.TODO(), key, flapsExpected)).To(Succeed())
+			g.Expect(flapsExpected.Status.Position).To(Equal(int32(20)))
+		}).WithTimeout(3 * time.Second).Should(Succeed())
+	})
+})
@@ -0,0 +1,145 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"reflect"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	playv1alpha1 "github.com/roehrich-hpe/airplane-sim/api/v1alpha1"
+)
+
+// FlapsReconciler reconciles a Flaps object
+type FlapsReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+}
+
+//+kubebuilder:rbac:groups=play.github.com,resources=flaps,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=play.github.com,resources=flaps/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=play.github.com,resources=flaps/finalizers,verbs=update
+
+// Reconcile moves the flaps toward their desired position at the flap
+// motor's rate, unless the airplane is too fast for the flaps to be
+// extended.
+//
+// For more details, check Reconcile and its Result here:
+// - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.12.2/pkg/reconcile
+func (r *FlapsReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := log.FromContext(ctx).WithName("flaps")
+
+	flaps := &playv1alpha1.Flaps{}
+	if err := r.Get(ctx, req.NamespacedName, flaps); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	clock, err := readClock(ctx, r.Client, req.Namespace)
+	if err != nil {
+		log.Error(err, "Unable to read simulation clock")
+		return ctrl.Result{}, err
+	}
+
+	airspeed, err := r.airspeed(ctx, flaps)
+	if err != nil {
+		log.Error(err, "Unable to get airspeed")
+		return ctrl.Result{}, err
+	}
+
+	spec := &flaps.Spec
+	target := clamp(spec.Position, 0, spec.MaxPosition)
+	overspeed := airspeed > spec.MaxExtensionSpeed
+	if overspeed {
+		// Too fast.  Refuse to extend, and bring in any flaps
+		// that are already out.
+		target = 0
+	}
+	position, lastMoved, wait := slew(flaps.Status.Position, target, spec.ExtensionRate, flaps.Status.LastMoved, clock.now)
+
+	status := &flaps.Status
+	if status.Position != position || status.Overspeed != overspeed || !lastMoved.Equal(status.LastMoved) {
+		if overspeed != status.Overspeed {
+			log.Info("Flap overspeed changed", "overspeed", overspeed, "airspeed", airspeed)
+		}
+		if position != status.Position {
+			log.Info("Moving flaps", "position", position, "target", target)
+		}
+		status.Position = position
+		status.Overspeed = overspeed
+		status.LastMoved = lastMoved
+		if err := r.Status().Update(ctx, flaps); err != nil {
+			if apierrors.IsConflict(err) {
+				log.Info("Conflict while setting position")
+				return ctrl.Result{Requeue: true}, nil
+			}
+			log.Error(err, "Error while setting position")
+			return ctrl.Result{}, err
+		}
+	}
+
+	return ctrl.Result{RequeueAfter: clock.wallTime(wait)}, nil
+}
+
+// airspeed returns the indicated airspeed of the airplane that owns the
+// flaps.  Flaps that don't belong to an airplane aren't going anywhere.
+func (r *FlapsReconciler) airspeed(ctx context.Context, flaps *playv1alpha1.Flaps) (int32, error) {
+	owner := metav1.GetControllerOf(flaps)
+	if owner == nil || owner.Kind != reflect.TypeOf(playv1alpha1.Airplane{}).Name() {
+		return 0, nil
+	}
+
+	airplane := &playv1alpha1.Airplane{}
+	key := types.NamespacedName{Name: owner.Name, Namespace: flaps.GetNamespace()}
+	if err := r.Get(ctx, key, airplane); err != nil {
+		return 0, client.IgnoreNotFound(err)
+	}
+	return airplane.Status.Airspeed, nil
+}
+
+// flapsForAirplane maps an airplane to its flaps, so the flaps can react to
+// changes in airspeed.
+func (r *FlapsReconciler) flapsForAirplane(obj client.Object) []reconcile.Request {
+	airplane, ok := obj.(*playv1alpha1.Airplane)
+	if !ok || len(airplane.Status.Flaps.Name) == 0 {
+		return nil
+	}
+	return []reconcile.Request{{
+		NamespacedName: types.NamespacedName{
+			Name:      airplane.Status.Flaps.Name,
+			Namespace: airplane.GetNamespace(),
+		},
+	}}
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *FlapsReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&playv1alpha1.Flaps{}).
+		Watches(&source.Kind{Type: &playv1alpha1.SimClock{}}, handler.EnqueueRequestsFromMapFunc(clockWatcher(mgr.GetClient(), &playv1alpha1.FlapsList{}))).
+		Watches(&source.Kind{Type: &playv1alpha1.Airplane{}}, handler.EnqueueRequestsFromMapFunc(r.flapsForAirplane)).
+		Complete(r)
+}
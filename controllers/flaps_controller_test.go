@@ -0,0 +1,143 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"strings"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	playv1alpha1 "github.com/roehrich-hpe/airplane-sim/api/v1alpha1"
+)
+
+var _ = Describe("Flaps Unit Tests", func() {
+
+	var (
+		key      types.NamespacedName
+		ckey     types.NamespacedName
+		airplane *playv1alpha1.Airplane
+	)
+
+	BeforeEach(func() {
+		key = types.NamespacedName{
+			Name:      uuid.New().String()[0:8],
+			Namespace: corev1.NamespaceDefault,
+		}
+
+		tailNumber := newTailNumber()
+		airplane = &playv1alpha1.Airplane{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      key.Name,
+				Namespace: key.Namespace,
+			},
+			Spec: playv1alpha1.AirplaneSpec{
+				TailNumber: tailNumber,
+			},
+		}
+
+		Expect(k8sClient.Create(context.TODO(), airplane)).To(Succeed())
+
+		ckey = types.NamespacedName{
+			Name:      strings.ToLower(tailNumber),
+			Namespace: key.Namespace,
+		}
+
+		// Wait for the airplane's flaps, and speed up the flap motor.
+		flaps := &playv1alpha1.Flaps{}
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(context.TODO(), ckey, flaps)).To(Succeed())
+			flaps.Spec.ExtensionRate = 30
+			g.Expect(k8sClient.Update(context.TODO(), flaps)).To(Succeed())
+		}).Should(Succeed())
+	})
+
+	AfterEach(func() {
+		Expect(k8sClient.Delete(context.TODO(), airplane)).To(Succeed())
+
+		// Test env limitation: Wait until the cached object is gone.
+		expected := &playv1alpha1.Airplane{}
+		Eventually(func() error {
+			return k8sClient.Get(context.TODO(), key, expected)
+		}).ShouldNot(Succeed())
+	})
+
+	setAirspeed := func(airspeed int32) {
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(context.TODO(), key, airplane)).To(Succeed())
+			airplane.Status.Airspeed = airspeed
+			g.Expect(k8sClient.Status().Update(context.TODO(), airplane)).To(Succeed())
+		}).Should(Succeed())
+	}
+
+	setFlaps := func(position int32) {
+		flaps := &playv1alpha1.Flaps{}
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(context.TODO(), ckey, flaps)).To(Succeed())
+			flaps.Spec.Position = position
+			g.Expect(k8sClient.Update(context.TODO(), flaps)).To(Succeed())
+		}).Should(Succeed())
+	}
+
+	It("Refuses to extend above the flap extension speed", func() {
+		setAirspeed(120)
+		setFlaps(10)
+
+		flaps := &playv1alpha1.Flaps{}
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(context.TODO(), ckey, flaps)).To(Succeed())
+			g.Expect(flaps.Status.Overspeed).To(BeTrue())
+		}).Should(Succeed())
+		Consistently(func(g Gomega) {
+			g.Expect(k8sClient.Get(context.TODO(), ckey, flaps)).To(Succeed())
+			g.Expect(flaps.Status.Position).To(BeZero())
+		}).Should(Succeed())
+
+		By("slowing down")
+		setAirspeed(70)
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(context.TODO(), ckey, flaps)).To(Succeed())
+			g.Expect(flaps.Status.Overspeed).To(BeFalse())
+			g.Expect(flaps.Status.Position).To(Equal(int32(10)))
+		}).Should(Succeed())
+	})
+
+	It("Retracts extended flaps above the flap extension speed", func() {
+		setAirspeed(70)
+		setFlaps(10)
+
+		flaps := &playv1alpha1.Flaps{}
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(context.TODO(), ckey, flaps)).To(Succeed())
+			g.Expect(flaps.Status.Position).To(Equal(int32(10)))
+		}).Should(Succeed())
+
+		By("speeding up")
+		setAirspeed(120)
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(context.TODO(), ckey, flaps)).To(Succeed())
+			g.Expect(flaps.Status.Overspeed).To(BeTrue())
+			g.Expect(flaps.Status.Position).To(BeZero())
+		}).Should(Succeed())
+	})
+})
@@ -0,0 +1,481 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	playv1alpha1 "github.com/roehrich-hpe/airplane-sim/api/v1alpha1"
+	"github.com/roehrich-hpe/airplane-sim/pkg/flightdata"
+)
+
+// flightDataKey is the key of the recording in the flight data ConfigMap.
+const flightDataKey = "flightdata.json"
+
+// FlightDataPath is where the manager serves recordings, next to its
+// metrics, when ServeRecordings is set.
+const FlightDataPath = "/flightdata"
+
+// flightDataAirspeedStep is how far, in knots, the airspeed has to change
+// before the recorder takes a sample for the airspeed alone.  The physics
+// updates the airspeed every tick, and recording each of those would
+// rewrite the ConfigMap twice a second.
+const flightDataAirspeedStep = 5
+
+// flightDataFlushInterval is the shortest time between two writes of an
+// airplane's recording.  A moving rudder changes every actuator tick, so
+// the samples taken in between are held and written together.
+const flightDataFlushInterval = time.Second
+
+// flightDataName is the name of the ConfigMap that holds an airplane's
+// recording.
+func flightDataName(airplaneName string) string {
+	return airplaneName + "-flightdata"
+}
+
+// FlightDataRecorderReconciler journals each airplane's controls and state.
+type FlightDataRecorderReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+
+	// APIReader reads the recordings straight from the API server, so the
+	// manager doesn't have to cache every ConfigMap in the cluster.
+	APIReader client.Reader
+
+	// Capacity is how many samples each airplane's recording holds.
+	// Zero means flightdata.DefaultCapacity.
+	Capacity int
+
+	// FlushInterval is the shortest time between two writes of an
+	// airplane's recording.  Zero means flightDataFlushInterval.
+	FlushInterval time.Duration
+
+	// pending holds the samples that haven't been written yet.
+	pending *sampleBuffer
+
+	// ServeRecordings serves the recordings at FlightDataPath on the
+	// metrics listener.  The handler does no authorization of its own, so
+	// this is only safe when the listener is bound to localhost and
+	// reached through kube-rbac-proxy.
+	ServeRecordings bool
+}
+
+//+kubebuilder:rbac:groups=play.github.com,resources=airplanes,verbs=get;list;watch
+//+kubebuilder:rbac:groups=play.github.com,resources=pedals,verbs=get;list;watch
+//+kubebuilder:rbac:groups=play.github.com,resources=rudders,verbs=get;list;watch
+//+kubebuilder:rbac:groups=play.github.com,resources=yokes,verbs=get;list;watch
+//+kubebuilder:rbac:groups=play.github.com,resources=trimwheels,verbs=get;list;watch
+//+kubebuilder:rbac:groups=play.github.com,resources=flaplevers,verbs=get;list;watch
+//+kubebuilder:rbac:groups=play.github.com,resources=gearlevers,verbs=get;list;watch
+//+kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;update
+
+// Reconcile takes a sample of the airplane's controls and state whenever
+// the airplane or one of its controls changes, and appends it to the
+// airplane's recording.  The recording is kept in a ConfigMap named after
+// the airplane, and is written at most once per FlushInterval; the samples
+// taken in between wait in memory.  A deleted airplane's samples are
+// written straight away, before the airplane is gone.  Like the final snapshot, it isn't owned by the airplane,
+// so it is still there for the debrief after the airplane is deleted.
+//
+// For more details, check Reconcile and its Result here:
+// - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.12.2/pkg/reconcile
+func (r *FlightDataRecorderReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := log.FromContext(ctx).WithName("flightdatarecorder")
+
+	airplane := &playv1alpha1.Airplane{}
+	if err := r.Get(ctx, req.NamespacedName, airplane); err != nil {
+		if apierrors.IsNotFound(err) {
+			r.pending.forget(req.NamespacedName)
+		}
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	clock, err := readClock(ctx, r.Client, req.Namespace)
+	if err != nil {
+		log.Error(err, "Unable to read simulation clock")
+		return ctrl.Result{}, err
+	}
+
+	sample, err := r.takeSample(ctx, airplane, clock.now)
+	if err != nil {
+		log.Error(err, "Unable to take sample")
+		return ctrl.Result{}, err
+	}
+
+	interval := r.FlushInterval
+	if interval <= 0 {
+		interval = flightDataFlushInterval
+	}
+	if !airplane.GetDeletionTimestamp().IsZero() {
+		interval = 0
+	}
+	samples, wait := r.pending.add(req.NamespacedName, airplane.GetUID(), sample, interval, time.Now())
+	if wait > 0 {
+		return ctrl.Result{RequeueAfter: wait}, nil
+	}
+	if len(samples) == 0 {
+		return ctrl.Result{}, nil
+	}
+
+	configMap := &corev1.ConfigMap{}
+	key := types.NamespacedName{Name: flightDataName(airplane.GetName()), Namespace: airplane.GetNamespace()}
+	if err := r.APIReader.Get(ctx, key, configMap); err != nil {
+		if !apierrors.IsNotFound(err) {
+			log.Error(err, "Unable to get recording")
+			return ctrl.Result{}, err
+		}
+		configMap = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      key.Name,
+				Namespace: key.Namespace,
+				Labels: map[string]string{
+					snapshotAirplaneLabel: airplane.GetName(),
+				},
+				Annotations: map[string]string{
+					snapshotAirplaneUIDAnnotation: string(airplane.GetUID()),
+				},
+			},
+		}
+	} else if configMap.GetLabels()[snapshotAirplaneLabel] != airplane.GetName() {
+		log.Info("Not overwriting someone else's ConfigMap with the recording", "configMap", key.Name)
+		return ctrl.Result{}, nil
+	}
+
+	recording := flightdata.New(r.Capacity)
+	if configMap.GetAnnotations()[snapshotAirplaneUIDAnnotation] == string(airplane.GetUID()) && len(configMap.Data[flightDataKey]) > 0 {
+		if recording, err = flightdata.Parse([]byte(configMap.Data[flightDataKey])); err != nil {
+			log.Error(err, "Unable to read recording, starting a new one")
+			recording = flightdata.New(r.Capacity)
+		}
+		if r.Capacity > 0 {
+			recording.Capacity = r.Capacity
+		}
+	}
+	// Otherwise it was left by an earlier airplane of the same name.
+	added := false
+	for _, sample := range samples {
+		if recording.Append(sample) {
+			added = true
+		}
+	}
+	if !added {
+		r.pending.written(req.NamespacedName, len(samples), time.Now())
+		return ctrl.Result{}, nil
+	}
+
+	data, err := recording.Encode()
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	configMap.Data = map[string]string{flightDataKey: string(data)}
+	metav1.SetMetaDataAnnotation(&configMap.ObjectMeta, snapshotAirplaneUIDAnnotation, string(airplane.GetUID()))
+	if len(configMap.GetResourceVersion()) == 0 {
+		err = r.Create(ctx, configMap)
+	} else {
+		err = r.Update(ctx, configMap)
+	}
+	if err != nil {
+		if apierrors.IsConflict(err) || apierrors.IsAlreadyExists(err) {
+			log.Info("Conflict while recording sample")
+			return ctrl.Result{Requeue: true}, nil
+		}
+		log.Error(err, "Unable to record sample")
+		return ctrl.Result{}, err
+	}
+	r.pending.written(req.NamespacedName, len(samples), time.Now())
+
+	return ctrl.Result{}, nil
+}
+
+// sampleBuffer holds each airplane's samples from the time they are taken
+// until they are written to its recording.
+type sampleBuffer struct {
+	sync.Mutex
+	airplanes map[types.NamespacedName]*pendingSamples
+}
+
+// pendingSamples are the samples an airplane has waiting to be written.
+type pendingSamples struct {
+	uid     types.UID
+	samples []flightdata.Sample
+	written time.Time
+}
+
+func newSampleBuffer() *sampleBuffer {
+	return &sampleBuffer{airplanes: map[types.NamespacedName]*pendingSamples{}}
+}
+
+// add holds a sample, unless it records the same state as the one before
+// it.  Once the interval has passed since the last write it returns the
+// samples to write; until then it returns how long to wait.
+func (b *sampleBuffer) add(key types.NamespacedName, uid types.UID, sample flightdata.Sample, interval time.Duration, now time.Time) ([]flightdata.Sample, time.Duration) {
+	b.Lock()
+	defer b.Unlock()
+
+	pending, ok := b.airplanes[key]
+	if !ok || pending.uid != uid {
+		// A new airplane, or a new one of the same name.
+		pending = &pendingSamples{uid: uid}
+		b.airplanes[key] = pending
+	}
+	if n := len(pending.samples); n == 0 || !pending.samples[n-1].SameAs(sample) {
+		pending.samples = append(pending.samples, sample)
+	}
+
+	if wait := pending.written.Add(interval).Sub(now); wait > 0 {
+		return nil, wait
+	}
+	return append([]flightdata.Sample{}, pending.samples...), 0
+}
+
+// written drops the first count samples, which are now in the recording.
+func (b *sampleBuffer) written(key types.NamespacedName, count int, now time.Time) {
+	b.Lock()
+	defer b.Unlock()
+
+	if pending, ok := b.airplanes[key]; ok {
+		if count > len(pending.samples) {
+			count = len(pending.samples)
+		}
+		pending.samples = append([]flightdata.Sample{}, pending.samples[count:]...)
+		pending.written = now
+	}
+}
+
+// forget drops the samples of an airplane that is gone.
+func (b *sampleBuffer) forget(key types.NamespacedName) {
+	b.Lock()
+	defer b.Unlock()
+
+	delete(b.airplanes, key)
+}
+
+// takeSample reads the airplane's controls and state as they are now.
+// Parts that haven't been built yet are left out of the sample.
+func (r *FlightDataRecorderReconciler) takeSample(ctx context.Context, airplane *playv1alpha1.Airplane, now time.Time) (flightdata.Sample, error) {
+	sample := flightdata.Sample{
+		Time:     now.UTC(),
+		Airspeed: airplane.Status.Airspeed,
+		Gear:     airplane.Status.Gear.State,
+		Ready:    meta.IsStatusConditionTrue(airplane.Status.Conditions, playv1alpha1.ConditionReady),
+	}
+
+	pedals := &playv1alpha1.Pedals{}
+	if found, err := getPart(ctx, r.Client, airplane.GetNamespace(), airplane.Status.Pedals, pedals); err != nil {
+		return sample, err
+	} else if found {
+		sample.Pressed = pedals.Spec.Pressed
+		sample.PedalTravel = pedalsTravel(&pedals.Spec)
+	}
+
+	rudder := &playv1alpha1.Rudder{}
+	if found, err := getPart(ctx, r.Client, airplane.GetNamespace(), airplane.Status.Rudder, rudder); err != nil {
+		return sample, err
+	} else if found {
+		sample.RudderCommanded = rudderTarget(&rudder.Spec)
+		sample.RudderDeflection = rudder.Status.Deflection
+	}
+
+	yoke := &playv1alpha1.Yoke{}
+	if found, err := getPart(ctx, r.Client, airplane.GetNamespace(), airplane.Status.Yoke, yoke); err != nil {
+		return sample, err
+	} else if found {
+		sample.Roll = yoke.Spec.Roll
+		sample.Pitch = yoke.Spec.Pitch
+	}
+
+	trimWheel := &playv1alpha1.TrimWheel{}
+	if found, err := getPart(ctx, r.Client, airplane.GetNamespace(), airplane.Status.TrimWheel, trimWheel); err != nil {
+		return sample, err
+	} else if found {
+		sample.Trim = trimWheel.Spec.Setting
+	}
+
+	flapLever := &playv1alpha1.FlapLever{}
+	if found, err := getPart(ctx, r.Client, airplane.GetNamespace(), airplane.Status.FlapLever, flapLever); err != nil {
+		return sample, err
+	} else if found {
+		sample.FlapLever = flapLever.Spec.Detent
+	}
+
+	gearLever := &playv1alpha1.GearLever{}
+	if found, err := getPart(ctx, r.Client, airplane.GetNamespace(), airplane.Status.GearLever, gearLever); err != nil {
+		return sample, err
+	} else if found {
+		sample.GearLever = gearLever.Spec.Position
+	}
+
+	return sample, nil
+}
+
+// getPart gets the part that an airplane's status refers to, and reports
+// whether it has been built.
+func getPart(ctx context.Context, c client.Reader, namespace string, ref corev1.ObjectReference, part client.Object) (bool, error) {
+	if len(ref.Name) == 0 {
+		return false, nil
+	}
+	if err := c.Get(ctx, types.NamespacedName{Name: ref.Name, Namespace: namespace}, part); err != nil {
+		return false, client.IgnoreNotFound(err)
+	}
+	return true, nil
+}
+
+// readRecording reads an airplane's recording.
+func readRecording(ctx context.Context, c client.Reader, key types.NamespacedName) (*flightdata.Recording, error) {
+	configMap := &corev1.ConfigMap{}
+	if err := c.Get(ctx, types.NamespacedName{Name: flightDataName(key.Name), Namespace: key.Namespace}, configMap); err != nil {
+		return nil, err
+	}
+	data, ok := configMap.Data[flightDataKey]
+	if !ok || configMap.GetLabels()[snapshotAirplaneLabel] != key.Name {
+		return nil, apierrors.NewNotFound(corev1.Resource("configmaps"), configMap.GetName())
+	}
+	return flightdata.Parse([]byte(data))
+}
+
+// flightDataHandler serves the samples that an airplane's recording holds
+// for a range of simulation time, as JSON:
+//
+//	GET /flightdata?namespace=default&airplane=cessna&from=2022-07-04T12:00:00Z&to=2022-07-04T12:05:00Z
+//
+// The namespace defaults to "default", and either end of the range can be
+// left off.  Anyone who can reach the handler can read every recording in
+// the cluster, so it must sit behind kube-rbac-proxy.
+type flightDataHandler struct {
+	reader client.Reader
+}
+
+func (h *flightDataHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet {
+		http.Error(w, "only GET is supported", http.StatusMethodNotAllowed)
+		return
+	}
+
+	query := req.URL.Query()
+	key := types.NamespacedName{Name: query.Get("airplane"), Namespace: query.Get("namespace")}
+	if len(key.Name) == 0 {
+		http.Error(w, "the airplane parameter is required", http.StatusBadRequest)
+		return
+	}
+	if len(key.Namespace) == 0 {
+		key.Namespace = corev1.NamespaceDefault
+	}
+
+	var times [2]time.Time
+	for i, name := range []string{"from", "to"} {
+		value := query.Get(name)
+		if len(value) == 0 {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339Nano, value)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("the %s parameter is not an RFC 3339 time: %v", name, err), http.StatusBadRequest)
+			return
+		}
+		times[i] = t
+	}
+
+	recording, err := readRecording(req.Context(), h.reader, key)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			http.Error(w, fmt.Sprintf("no recording for airplane %s", key), http.StatusNotFound)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(recording.Between(times[0], times[1]))
+}
+
+// airplaneSampleChanged passes airplane updates that change what the
+// recorder samples from the airplane itself, or the parts it reads the rest
+// of the sample from.  Small changes in airspeed are left for the next
+// sample to pick up.
+func airplaneSampleChanged() predicate.Predicate {
+	return predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			old, ok := e.ObjectOld.(*playv1alpha1.Airplane)
+			if !ok {
+				return true
+			}
+			airplane, ok := e.ObjectNew.(*playv1alpha1.Airplane)
+			if !ok {
+				return true
+			}
+			if old.Status.Airspeed/flightDataAirspeedStep != airplane.Status.Airspeed/flightDataAirspeedStep {
+				return true
+			}
+			if old.Status.Gear.State != airplane.Status.Gear.State {
+				return true
+			}
+			if meta.IsStatusConditionTrue(old.Status.Conditions, playv1alpha1.ConditionReady) != meta.IsStatusConditionTrue(airplane.Status.Conditions, playv1alpha1.ConditionReady) {
+				return true
+			}
+			return old.Status.Pedals != airplane.Status.Pedals ||
+				old.Status.Rudder != airplane.Status.Rudder ||
+				old.Status.Yoke != airplane.Status.Yoke ||
+				old.Status.TrimWheel != airplane.Status.TrimWheel ||
+				old.Status.FlapLever != airplane.Status.FlapLever ||
+				old.Status.GearLever != airplane.Status.GearLever
+		},
+	}
+}
+
+// SetupWithManager sets up the controller with the Manager, and serves the
+// recordings from the manager's metrics server if asked to.
+func (r *FlightDataRecorderReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	r.pending = newSampleBuffer()
+	if r.ServeRecordings {
+		if err := mgr.AddMetricsExtraHandler(FlightDataPath, &flightDataHandler{reader: r.APIReader}); err != nil {
+			return err
+		}
+	}
+
+	owner := &handler.EnqueueRequestForOwner{OwnerType: &playv1alpha1.Airplane{}, IsController: true}
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("flightdatarecorder").
+		For(&playv1alpha1.Airplane{}, builder.WithPredicates(airplaneSampleChanged())).
+		Watches(&source.Kind{Type: &playv1alpha1.Pedals{}}, owner).
+		Watches(&source.Kind{Type: &playv1alpha1.Rudder{}}, owner).
+		Watches(&source.Kind{Type: &playv1alpha1.Yoke{}}, owner).
+		Watches(&source.Kind{Type: &playv1alpha1.TrimWheel{}}, owner).
+		Watches(&source.Kind{Type: &playv1alpha1.FlapLever{}}, owner).
+		Watches(&source.Kind{Type: &playv1alpha1.GearLever{}}, owner).
+		Complete(r)
+}
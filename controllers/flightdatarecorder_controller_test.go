@@ -0,0 +1,214 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	playv1alpha1 "github.com/roehrich-hpe/airplane-sim/api/v1alpha1"
+	"github.com/roehrich-hpe/airplane-sim/pkg/flightdata"
+)
+
+var _ = Describe("FlightDataRecorder Unit Tests", func() {
+
+	var (
+		key      types.NamespacedName
+		ckey     types.NamespacedName
+		airplane *playv1alpha1.Airplane
+	)
+
+	BeforeEach(func() {
+		key = types.NamespacedName{
+			Name:      uuid.New().String()[0:8],
+			Namespace: corev1.NamespaceDefault,
+		}
+
+		tailNumber := newTailNumber()
+		airplane = &playv1alpha1.Airplane{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      key.Name,
+				Namespace: key.Namespace,
+			},
+			Spec: playv1alpha1.AirplaneSpec{
+				TailNumber: tailNumber,
+			},
+		}
+
+		Expect(k8sClient.Create(context.TODO(), airplane)).To(Succeed())
+
+		ckey = types.NamespacedName{
+			Name:      strings.ToLower(tailNumber),
+			Namespace: key.Namespace,
+		}
+	})
+
+	AfterEach(func() {
+		Expect(k8sClient.Delete(context.TODO(), airplane)).To(Succeed())
+
+		// Test env limitation: Wait until the cached object is gone.
+		expected := &playv1alpha1.Airplane{}
+		Eventually(func() error {
+			return k8sClient.Get(context.TODO(), key, expected)
+		}).WithTimeout(5 * time.Second).ShouldNot(Succeed())
+
+		// The recording outlives the airplane.
+		configMap := &corev1.ConfigMap{}
+		Expect(k8sClient.Get(context.TODO(), types.NamespacedName{Name: flightDataName(key.Name), Namespace: key.Namespace}, configMap)).To(Succeed())
+		Expect(k8sClient.Delete(context.TODO(), configMap)).To(Succeed())
+	})
+
+	fetch := func(query string) *httptest.ResponseRecorder {
+		response := httptest.NewRecorder()
+		request := httptest.NewRequest(http.MethodGet, FlightDataPath+"?"+query, nil)
+		(&flightDataHandler{reader: k8sClient}).ServeHTTP(response, request)
+		return response
+	}
+
+	It("Records the pedals and rudder", func() {
+		pedals := &playv1alpha1.Pedals{}
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(context.TODO(), ckey, pedals)).To(Succeed())
+			pedals.Spec.Pressed = "left"
+			g.Expect(k8sClient.Update(context.TODO(), pedals)).To(Succeed())
+		}).Should(Succeed())
+
+		rudder := &playv1alpha1.Rudder{}
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(context.TODO(), ckey, rudder)).To(Succeed())
+			g.Expect(rudder.Status.Position).To(Equal("left"))
+		}).Should(Succeed())
+
+		var recording *flightdata.Recording
+		Eventually(func(g Gomega) {
+			var err error
+			recording, err = readRecording(context.TODO(), k8sClient, key)
+			g.Expect(err).NotTo(HaveOccurred())
+			last, ok := recording.Last()
+			g.Expect(ok).To(BeTrue())
+			g.Expect(last.Pressed).To(Equal("left"))
+			g.Expect(last.PedalTravel).To(Equal(int32(-100)))
+			g.Expect(last.RudderCommanded).To(Equal(*rudder.Spec.MinDeflection))
+			g.Expect(last.RudderDeflection).To(Equal(*rudder.Spec.MinDeflection))
+		}).WithTimeout(3 * time.Second).Should(Succeed())
+		Expect(len(recording.Samples)).To(BeNumerically(">", 1))
+
+		By("fetching a time range")
+		last, _ := recording.Last()
+		response := fetch("airplane=" + key.Name + "&from=" + last.Time.Format(time.RFC3339Nano))
+		Expect(response.Code).To(Equal(http.StatusOK))
+		samples := []flightdata.Sample{}
+		Expect(json.Unmarshal(response.Body.Bytes(), &samples)).To(Succeed())
+		Expect(samples).To(HaveLen(1))
+		Expect(samples[0].Pressed).To(Equal("left"))
+
+		response = fetch("namespace=" + key.Namespace + "&airplane=" + key.Name)
+		Expect(response.Code).To(Equal(http.StatusOK))
+		Expect(json.Unmarshal(response.Body.Bytes(), &samples)).To(Succeed())
+		Expect(len(samples)).To(BeNumerically(">=", len(recording.Samples)))
+	})
+
+	It("Rejects bad requests", func() {
+		Expect(fetch("namespace=" + key.Namespace).Code).To(Equal(http.StatusBadRequest))
+		Expect(fetch("airplane=" + key.Name + "&from=yesterday").Code).To(Equal(http.StatusBadRequest))
+		Expect(fetch("airplane=no-such-airplane").Code).To(Equal(http.StatusNotFound))
+
+		// Wait for the recording, so there's one to clean up.
+		Eventually(func() error {
+			_, err := readRecording(context.TODO(), k8sClient, key)
+			return err
+		}).Should(Succeed())
+	})
+
+	It("Holds the samples taken between writes", func() {
+		buffer := newSampleBuffer()
+		now := time.Now()
+		sample := func(pressed string) flightdata.Sample {
+			return flightdata.Sample{Time: now, Pressed: pressed}
+		}
+
+		samples, wait := buffer.add(key, "first", sample("left"), time.Second, now)
+		Expect(wait).To(BeZero())
+		Expect(samples).To(HaveLen(1))
+		buffer.written(key, len(samples), now)
+
+		By("taking samples before the interval is up")
+		samples, wait = buffer.add(key, "first", sample("right"), time.Second, now.Add(100*time.Millisecond))
+		Expect(samples).To(BeEmpty())
+		Expect(wait).To(Equal(900 * time.Millisecond))
+		_, wait = buffer.add(key, "first", sample("right"), time.Second, now.Add(200*time.Millisecond))
+		Expect(wait).To(Equal(800 * time.Millisecond))
+		_, wait = buffer.add(key, "first", sample("none"), time.Second, now.Add(300*time.Millisecond))
+		Expect(wait).To(Equal(700 * time.Millisecond))
+
+		By("writing them together once it is")
+		samples, wait = buffer.add(key, "first", sample("none"), time.Second, now.Add(time.Second))
+		Expect(wait).To(BeZero())
+		Expect(samples).To(HaveLen(2))
+		Expect(samples[0].Pressed).To(Equal("right"))
+		Expect(samples[1].Pressed).To(Equal("none"))
+		buffer.written(key, len(samples), now.Add(time.Second))
+
+		By("starting over for a new airplane of the same name")
+		samples, wait = buffer.add(key, "second", sample("left"), time.Second, now.Add(1100*time.Millisecond))
+		Expect(wait).To(BeZero())
+		Expect(samples).To(HaveLen(1))
+
+		// Wait for the recording, so there's one to clean up.
+		Eventually(func() error {
+			_, err := readRecording(context.TODO(), k8sClient, key)
+			return err
+		}).Should(Succeed())
+	})
+
+	It("Samples the airplane only when something it records changes", func() {
+		Eventually(func() error {
+			return k8sClient.Get(context.TODO(), key, airplane)
+		}).Should(Succeed())
+		changed := airplaneSampleChanged()
+		update := func(change func(status *playv1alpha1.AirplaneStatus)) bool {
+			updated := airplane.DeepCopy()
+			change(&updated.Status)
+			return changed.Update(event.UpdateEvent{ObjectOld: airplane, ObjectNew: updated})
+		}
+
+		airplane.Status.Airspeed = 60
+		Expect(update(func(status *playv1alpha1.AirplaneStatus) { status.Airspeed = 61 })).To(BeFalse())
+		Expect(update(func(status *playv1alpha1.AirplaneStatus) { status.Airspeed = 66 })).To(BeTrue())
+		Expect(update(func(status *playv1alpha1.AirplaneStatus) { status.Gear.State = playv1alpha1.GearUnsafe })).To(BeTrue())
+		Expect(update(func(status *playv1alpha1.AirplaneStatus) { status.ObservedGeneration++ })).To(BeFalse())
+
+		// Wait for the recording, so there's one to clean up.
+		Eventually(func() error {
+			_, err := readRecording(context.TODO(), k8sClient, key)
+			return err
+		}).Should(Succeed())
+	})
+})
@@ -0,0 +1,102 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	playv1alpha1 "github.com/roehrich-hpe/airplane-sim/api/v1alpha1"
+)
+
+// GearLinkageReconciler reconciles the linkage between a GearLever and its
+// LandingGear
+type GearLinkageReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+}
+
+//+kubebuilder:rbac:groups=play.github.com,resources=gearlevers,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=play.github.com,resources=gearlevers/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=play.github.com,resources=gearlevers/finalizers,verbs=update
+
+// Reconcile moves the gear linkage to follow the lever, and asks the landing
+// gear to follow the linkage.
+//
+// For more details, check Reconcile and its Result here:
+// - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.12.2/pkg/reconcile
+func (r *GearLinkageReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := log.FromContext(ctx).WithName("gearlinkage")
+
+	gearLever := &playv1alpha1.GearLever{}
+	if err := r.Get(ctx, req.NamespacedName, gearLever); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	position := gearLever.Spec.Position
+	if len(position) == 0 {
+		position = "down"
+	}
+	if gearLever.Status.Position != position {
+		log.Info("Resetting gear linkage")
+		gearLever.Status.Position = position
+		if err := r.Status().Update(ctx, gearLever); err != nil {
+			if apierrors.IsConflict(err) {
+				log.Info("Conflict while setting gear linkage")
+				return ctrl.Result{Requeue: true}, nil
+			}
+			log.Error(err, "Error while setting gear linkage")
+			return ctrl.Result{}, err
+		}
+	}
+
+	// Get the landing gear, move it if necessary.
+	landingGear := &playv1alpha1.LandingGear{}
+	// LandingGear and GearLever have the same name.
+	landingGearKey := req.NamespacedName
+	if err := r.Get(ctx, landingGearKey, landingGear); err != nil {
+		log.Error(err, "Did not find landing gear")
+		return ctrl.Result{RequeueAfter: time.Second * 10}, nil
+	}
+
+	if landingGear.Spec.Position != gearLever.Status.Position {
+		landingGear.Spec.Position = gearLever.Status.Position
+		if err := r.Update(ctx, landingGear, client.FieldOwner(playv1alpha1.LinkageFieldManager)); err != nil {
+			if apierrors.IsConflict(err) {
+				log.Info("Conflict on landing gear")
+				return ctrl.Result{Requeue: true}, nil
+			}
+			log.Error(err, "Error on landing gear")
+			return ctrl.Result{}, err
+		}
+		log.Info("landing gear has been set")
+	}
+	return ctrl.Result{}, nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *GearLinkageReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&playv1alpha1.GearLever{}).
+		Complete(r)
+}
@@ -0,0 +1,120 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	playv1alpha1 "github.com/roehrich-hpe/airplane-sim/api/v1alpha1"
+)
+
+var _ = Describe("GearLinkage Unit Tests", func() {
+
+	var (
+		key       types.NamespacedName
+		gearLever *playv1alpha1.GearLever
+
+		landingGear *playv1alpha1.LandingGear
+	)
+
+	BeforeEach(func() {
+		// GearLever and landing gear have the same value for the name.
+		key = types.NamespacedName{
+			Name:      uuid.New().String()[0:8],
+			Namespace: corev1.NamespaceDefault,
+		}
+
+		gearLever = &playv1alpha1.GearLever{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      key.Name,
+				Namespace: key.Namespace,
+			},
+		}
+
+		Expect(k8sClient.Create(context.TODO(), gearLever)).To(Succeed())
+
+		landingGear = &playv1alpha1.LandingGear{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      key.Name,
+				Namespace: key.Namespace,
+			},
+			Spec: playv1alpha1.LandingGearSpec{
+				ExtensionTime:  &metav1.Duration{Duration: time.Second},
+				RetractionTime: &metav1.Duration{Duration: time.Second},
+			},
+		}
+
+		Expect(k8sClient.Create(context.TODO(), landingGear)).To(Succeed())
+	})
+
+	AfterEach(func() {
+		Expect(k8sClient.Delete(context.TODO(), gearLever)).To(Succeed())
+		Expect(k8sClient.Delete(context.TODO(), landingGear)).To(Succeed())
+
+		// Test env limitation: Wait until the cached object is gone.
+		expected := &playv1alpha1.GearLever{}
+		Eventually(func() error {
+			return k8sClient.Get(context.TODO(), key, expected)
+		}).ShouldNot(Succeed())
+	})
+
+	It("Starts with the gear down and locked", func() {
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(context.TODO(), key, landingGear)).To(Succeed())
+			g.Expect(landingGear.Status.State).To(Equal(playv1alpha1.GearDownLocked))
+		}).Should(Succeed())
+	})
+
+	It("Retracts the gear through transit", func() {
+		By("raising the lever")
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(context.TODO(), key, gearLever)).To(Succeed())
+			gearLever.Spec.Position = "up"
+			g.Expect(k8sClient.Update(context.TODO(), gearLever)).To(Succeed())
+		}).Should(Succeed())
+
+		By("watching the linkage move")
+		expected := &playv1alpha1.GearLever{}
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(context.TODO(), key, expected)).To(Succeed())
+			g.Expect(expected.Status.Position).To(Equal("up"))
+		}).Should(Succeed())
+
+		By("watching the gear go into transit")
+		gearExpected := &playv1alpha1.LandingGear{}
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(context.TODO(), key, gearExpected)).To(Succeed())
+			g.Expect(gearExpected.Status.State).To(Equal(playv1alpha1.GearInTransit))
+		}).Should(Succeed())
+
+		By("waiting for the gear to lock up")
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(context.TODO(), key, gearExpected)).To(Succeed())
+			g.Expect(gearExpected.Status.State).To(Equal(playv1alpha1.GearUpLocked))
+			g.Expect(gearExpected.Status.TransitStarted).To(BeNil())
+		}).WithTimeout(3 * time.Second).Should(Succeed())
+	})
+})
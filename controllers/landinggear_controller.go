@@ -0,0 +1,232 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"reflect"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	playv1alpha1 "github.com/roehrich-hpe/airplane-sim/api/v1alpha1"
+)
+
+// LandingGearReconciler reconciles a LandingGear object
+type LandingGearReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+}
+
+//+kubebuilder:rbac:groups=play.github.com,resources=landinggears,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=play.github.com,resources=landinggears/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=play.github.com,resources=landinggears/finalizers,verbs=update
+
+// Reconcile sequences the landing gear between down-locked and up-locked,
+// taking the configured time for each transit.  The squat switch keeps the
+// gear down while the airplane has weight on its wheels.
+//
+// For more details, check Reconcile and its Result here:
+// - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.12.2/pkg/reconcile
+func (r *LandingGearReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := log.FromContext(ctx).WithName("landinggear")
+
+	landingGear := &playv1alpha1.LandingGear{}
+	if err := r.Get(ctx, req.NamespacedName, landingGear); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	clock, err := readClock(ctx, r.Client, req.Namespace)
+	if err != nil {
+		log.Error(err, "Unable to read simulation clock")
+		return ctrl.Result{}, err
+	}
+
+	weightOnWheels, err := r.weightOnWheels(ctx, landingGear)
+	if err != nil {
+		log.Error(err, "Unable to check squat switch")
+		return ctrl.Result{}, err
+	}
+
+	target := landingGear.Spec.Position
+	if len(target) == 0 {
+		target = "down"
+	}
+	blocked := target == "up" && weightOnWheels
+	if blocked {
+		target = "down"
+	}
+
+	status := landingGear.Status.DeepCopy()
+	status.RetractionBlocked = blocked
+	wait := sequenceGear(status, &landingGear.Spec, target, clock.now)
+
+	if !reflect.DeepEqual(status, &landingGear.Status) {
+		if status.State != landingGear.Status.State {
+			log.Info("Gear state changed", "state", status.State, "target", status.Target)
+		}
+		if status.RetractionBlocked != landingGear.Status.RetractionBlocked {
+			log.Info("Squat switch changed", "retractionBlocked", status.RetractionBlocked)
+		}
+		landingGear.Status = *status
+		if err := r.Status().Update(ctx, landingGear); err != nil {
+			if apierrors.IsConflict(err) {
+				log.Info("Conflict while setting state")
+				return ctrl.Result{Requeue: true}, nil
+			}
+			log.Error(err, "Error while setting state")
+			return ctrl.Result{}, err
+		}
+	}
+
+	return ctrl.Result{RequeueAfter: clock.wallTime(wait)}, nil
+}
+
+// sequenceGear moves the gear state along toward the target position, and
+// returns how long to wait before the state should be checked again.  The
+// wait is zero when the gear is not moving.
+func sequenceGear(status *playv1alpha1.LandingGearStatus, spec *playv1alpha1.LandingGearSpec, target string, now time.Time) time.Duration {
+	if len(status.State) == 0 {
+		// New gear starts out down and locked.
+		status.State = playv1alpha1.GearDownLocked
+		status.Target = "down"
+	}
+
+	switch status.State {
+	case playv1alpha1.GearInTransit:
+		if status.TransitStarted == nil || status.TransitStarted.After(now) {
+			// The clock went backwards, which happens when a
+			// SimClock is removed.  Start timing again from here.
+			restarted := metav1.NewMicroTime(now)
+			status.TransitStarted = &restarted
+		}
+		started := status.TransitStarted.Time
+		if status.Target != target {
+			// Reversed in mid-transit.  The gear only has to come
+			// back as far as it went.
+			done := float64(now.Sub(started)) / float64(transitTime(spec, status.Target))
+			if done > 1 {
+				done = 1
+			}
+			started = now.Add(-time.Duration((1 - done) * float64(transitTime(spec, target))))
+			status.Target = target
+			transitStarted := metav1.NewMicroTime(started)
+			status.TransitStarted = &transitStarted
+		}
+
+		remaining := transitTime(spec, status.Target) - now.Sub(started)
+		if remaining > 0 {
+			return remaining
+		}
+		status.TransitStarted = nil
+		if spec.Fault {
+			status.State = playv1alpha1.GearUnsafe
+		} else {
+			status.State = lockedState(status.Target)
+		}
+		return 0
+	case playv1alpha1.GearUnsafe:
+		if spec.Fault && status.Target == target {
+			// Stuck until the fault clears or the lever moves.
+			return 0
+		}
+	default:
+		if status.State == lockedState(target) {
+			return 0
+		}
+	}
+
+	// Start a new transit.
+	transitStarted := metav1.NewMicroTime(now)
+	status.State = playv1alpha1.GearInTransit
+	status.Target = target
+	status.TransitStarted = &transitStarted
+	return transitTime(spec, target)
+}
+
+// transitTime is how long the gear takes to reach the given position.
+func transitTime(spec *playv1alpha1.LandingGearSpec, position string) time.Duration {
+	duration := spec.ExtensionTime
+	if position == "up" {
+		duration = spec.RetractionTime
+	}
+	if duration == nil {
+		return 0
+	}
+	return duration.Duration
+}
+
+// lockedState is the state of gear that has finished moving to the given
+// position.
+func lockedState(position string) string {
+	if position == "up" {
+		return playv1alpha1.GearUpLocked
+	}
+	return playv1alpha1.GearDownLocked
+}
+
+// weightOnWheels reads the squat switch of the airplane that owns the
+// landing gear.  Gear that doesn't belong to an airplane has no squat switch.
+func (r *LandingGearReconciler) weightOnWheels(ctx context.Context, landingGear *playv1alpha1.LandingGear) (bool, error) {
+	owner := metav1.GetControllerOf(landingGear)
+	if owner == nil || owner.Kind != reflect.TypeOf(playv1alpha1.Airplane{}).Name() {
+		return false, nil
+	}
+
+	airplane := &playv1alpha1.Airplane{}
+	key := types.NamespacedName{Name: owner.Name, Namespace: landingGear.GetNamespace()}
+	if err := r.Get(ctx, key, airplane); err != nil {
+		return false, client.IgnoreNotFound(err)
+	}
+	if airplane.Status.WeightOnWheels == nil {
+		return true, nil
+	}
+	return *airplane.Status.WeightOnWheels, nil
+}
+
+// landingGearForAirplane maps an airplane to its landing gear, so the gear
+// can react to the squat switch.
+func (r *LandingGearReconciler) landingGearForAirplane(obj client.Object) []reconcile.Request {
+	airplane, ok := obj.(*playv1alpha1.Airplane)
+	if !ok || len(airplane.Status.LandingGear.Name) == 0 {
+		return nil
+	}
+	return []reconcile.Request{{
+		NamespacedName: types.NamespacedName{
+			Name:      airplane.Status.LandingGear.Name,
+			Namespace: airplane.GetNamespace(),
+		},
+	}}
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *LandingGearReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&playv1alpha1.LandingGear{}).
+		Watches(&source.Kind{Type: &playv1alpha1.SimClock{}}, handler.EnqueueRequestsFromMapFunc(clockWatcher(mgr.GetClient(), &playv1alpha1.LandingGearList{}))).
+		Watches(&source.Kind{Type: &playv1alpha1.Airplane{}}, handler.EnqueueRequestsFromMapFunc(r.landingGearForAirplane)).
+		Complete(r)
+}
@@ -0,0 +1,146 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	playv1alpha1 "github.com/roehrich-hpe/airplane-sim/api/v1alpha1"
+)
+
+var _ = Describe("LandingGear Unit Tests", func() {
+
+	var (
+		key      types.NamespacedName
+		ckey     types.NamespacedName
+		airplane *playv1alpha1.Airplane
+	)
+
+	BeforeEach(func() {
+		key = types.NamespacedName{
+			Name:      uuid.New().String()[0:8],
+			Namespace: corev1.NamespaceDefault,
+		}
+
+		tailNumber := newTailNumber()
+		airplane = &playv1alpha1.Airplane{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      key.Name,
+				Namespace: key.Namespace,
+			},
+			Spec: playv1alpha1.AirplaneSpec{
+				TailNumber: tailNumber,
+			},
+		}
+
+		Expect(k8sClient.Create(context.TODO(), airplane)).To(Succeed())
+
+		ckey = types.NamespacedName{
+			Name:      strings.ToLower(tailNumber),
+			Namespace: key.Namespace,
+		}
+
+		// Wait for the airplane's gear, and speed it up.
+		landingGear := &playv1alpha1.LandingGear{}
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(context.TODO(), ckey, landingGear)).To(Succeed())
+			landingGear.Spec.ExtensionTime = &metav1.Duration{Duration: time.Second}
+			landingGear.Spec.RetractionTime = &metav1.Duration{Duration: time.Second}
+			g.Expect(k8sClient.Update(context.TODO(), landingGear)).To(Succeed())
+		}).Should(Succeed())
+	})
+
+	AfterEach(func() {
+		Expect(k8sClient.Delete(context.TODO(), airplane)).To(Succeed())
+
+		// Test env limitation: Wait until the cached object is gone.
+		expected := &playv1alpha1.Airplane{}
+		Eventually(func() error {
+			return k8sClient.Get(context.TODO(), key, expected)
+		}).ShouldNot(Succeed())
+	})
+
+	setWeightOnWheels := func(weightOnWheels bool) {
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(context.TODO(), key, airplane)).To(Succeed())
+			airplane.Status.WeightOnWheels = &weightOnWheels
+			g.Expect(k8sClient.Status().Update(context.TODO(), airplane)).To(Succeed())
+		}).Should(Succeed())
+	}
+
+	setGearLever := func(position string) {
+		gearLever := &playv1alpha1.GearLever{}
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(context.TODO(), ckey, gearLever)).To(Succeed())
+			gearLever.Spec.Position = position
+			g.Expect(k8sClient.Update(context.TODO(), gearLever)).To(Succeed())
+		}).Should(Succeed())
+	}
+
+	It("Blocks retraction while there is weight on the wheels", func() {
+		setGearLever("up")
+
+		By("checking the squat switch holds the gear down")
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(context.TODO(), key, airplane)).To(Succeed())
+			g.Expect(airplane.Status.Gear.Lever).To(Equal("up"))
+			g.Expect(airplane.Status.Gear.RetractionBlocked).To(BeTrue())
+		}).Should(Succeed())
+		landingGear := &playv1alpha1.LandingGear{}
+		Consistently(func(g Gomega) {
+			g.Expect(k8sClient.Get(context.TODO(), ckey, landingGear)).To(Succeed())
+			g.Expect(landingGear.Status.State).To(Equal(playv1alpha1.GearDownLocked))
+		}).Should(Succeed())
+
+		By("lifting off")
+		setWeightOnWheels(false)
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(context.TODO(), key, airplane)).To(Succeed())
+			g.Expect(airplane.Status.Gear.RetractionBlocked).To(BeFalse())
+			g.Expect(airplane.Status.Gear.State).To(Equal(playv1alpha1.GearUpLocked))
+		}).WithTimeout(3 * time.Second).Should(Succeed())
+	})
+
+	It("Reports unsafe gear when the gear fails to lock", func() {
+		setWeightOnWheels(false)
+		landingGear := &playv1alpha1.LandingGear{}
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(context.TODO(), ckey, landingGear)).To(Succeed())
+			landingGear.Spec.Fault = true
+			g.Expect(k8sClient.Update(context.TODO(), landingGear)).To(Succeed())
+		}).Should(Succeed())
+		setGearLever("up")
+
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(context.TODO(), key, airplane)).To(Succeed())
+			g.Expect(airplane.Status.Gear.State).To(Equal(playv1alpha1.GearUnsafe))
+			g.Expect(meta.IsStatusConditionTrue(airplane.Status.Conditions, playv1alpha1.ConditionDegraded)).To(BeTrue())
+			g.Expect(meta.IsStatusConditionFalse(airplane.Status.Conditions, playv1alpha1.ConditionReady)).To(BeTrue())
+		}).WithTimeout(3 * time.Second).Should(Succeed())
+	})
+})
@@ -0,0 +1,412 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	playv1alpha1 "github.com/roehrich-hpe/airplane-sim/api/v1alpha1"
+)
+
+// linkageRefIndex indexes linkages by the parts at either end, as
+// "Kind/name", so a change to a part finds the linkages attached to it.
+const linkageRefIndex = ".spec.refs"
+
+// linkableParts are the kinds a linkage may connect.  This must match the
+// enum on FieldReference.Kind.
+var linkableParts = []client.Object{
+	&playv1alpha1.Pedals{},
+	&playv1alpha1.Rudder{},
+	&playv1alpha1.Yoke{},
+	&playv1alpha1.Aileron{},
+	&playv1alpha1.Elevator{},
+	&playv1alpha1.TrimWheel{},
+	&playv1alpha1.FlapLever{},
+	&playv1alpha1.Flaps{},
+	&playv1alpha1.GearLever{},
+	&playv1alpha1.LandingGear{},
+	&playv1alpha1.Airplane{},
+}
+
+// builtInLinkage is a linkage the simulator rigs itself, from a control to
+// the part with the same name.
+type builtInLinkage struct {
+	sourceKind string
+	targetKind string
+	fields     []string
+}
+
+// builtInLinkages are the fields the built-in linkages drive.  A Linkage
+// that drives one of them would fight the built-in linkage for it.
+var builtInLinkages = []builtInLinkage{
+	{"Pedals", "Rudder", []string{"spec.position", "spec.deflection"}},
+	{"Yoke", "Aileron", []string{"spec.left", "spec.right"}},
+	{"Yoke", "Elevator", []string{"spec.deflection"}},
+	{"TrimWheel", "Elevator", []string{"spec.trim"}},
+	{"FlapLever", "Flaps", []string{"spec.position"}},
+	{"GearLever", "LandingGear", []string{"spec.position"}},
+}
+
+// drivenBy returns the built-in linkage that drives a field, if any.
+func drivenBy(ref *playv1alpha1.FieldReference) *builtInLinkage {
+	for i := range builtInLinkages {
+		builtIn := &builtInLinkages[i]
+		if builtIn.targetKind != ref.Kind {
+			continue
+		}
+		for _, field := range builtIn.fields {
+			if field == ref.Field {
+				return builtIn
+			}
+		}
+	}
+	return nil
+}
+
+// LinkageReconciler reconciles a Linkage object
+type LinkageReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+}
+
+// linkageFault is a problem with the parts at the ends of a linkage.  It is
+// reported in the linkage's conditions rather than returned as an error,
+// since retrying won't help until one of the parts changes.
+type linkageFault struct {
+	reason  string
+	message string
+}
+
+//+kubebuilder:rbac:groups=play.github.com,resources=linkages,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=play.github.com,resources=linkages/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=play.github.com,resources=linkages/finalizers,verbs=update
+
+// Reconcile reads the linkage's source field, applies the linkage's rigging,
+// and drives the target field to the result.
+//
+// For more details, check Reconcile and its Result here:
+// - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.12.2/pkg/reconcile
+func (r *LinkageReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := log.FromContext(ctx).WithName("linkage")
+
+	linkage := &playv1alpha1.Linkage{}
+	if err := r.Get(ctx, req.NamespacedName, linkage); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	status := linkage.Status.DeepCopy()
+	status.ObservedGeneration = linkage.Generation
+
+	fault, err := r.drive(ctx, linkage, status)
+	if err != nil {
+		if apierrors.IsConflict(err) {
+			log.Info("Conflict on target")
+			return ctrl.Result{Requeue: true}, nil
+		}
+		log.Error(err, "Unable to drive target")
+		return ctrl.Result{}, err
+	}
+
+	if fault != nil {
+		// Nothing to do until one of the parts changes, and the watches
+		// on the parts will tell us when it does.
+		if !meta.IsStatusConditionTrue(status.Conditions, playv1alpha1.ConditionLinkageBroken) {
+			log.Info("Linkage is broken", "reason", fault.reason, "message", fault.message)
+		}
+		setCondition(&status.Conditions, linkage.Generation, playv1alpha1.ConditionLinkageBroken, true, fault.reason, fault.message)
+		setCondition(&status.Conditions, linkage.Generation, playv1alpha1.ConditionControlsLinked, false, fault.reason, fault.message)
+		setCondition(&status.Conditions, linkage.Generation, playv1alpha1.ConditionReady, false, fault.reason, "")
+	} else {
+		setCondition(&status.Conditions, linkage.Generation, playv1alpha1.ConditionLinkageBroken, false, "Linked", "")
+		setCondition(&status.Conditions, linkage.Generation, playv1alpha1.ConditionControlsLinked, true, "Linked", "")
+		setCondition(&status.Conditions, linkage.Generation, playv1alpha1.ConditionReady, true, "TargetSet", "")
+	}
+
+	if !equality.Semantic.DeepEqual(status, &linkage.Status) {
+		linkage.Status = *status
+		if err := r.Status().Update(ctx, linkage); err != nil {
+			if apierrors.IsConflict(err) {
+				log.Info("Conflict while setting status")
+				return ctrl.Result{Requeue: true}, nil
+			}
+			log.Error(err, "Error while setting status")
+			return ctrl.Result{}, err
+		}
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// drive moves the target to follow the source, and records both values in
+// the status.
+func (r *LinkageReconciler) drive(ctx context.Context, linkage *playv1alpha1.Linkage, status *playv1alpha1.LinkageStatus) (*linkageFault, error) {
+	spec := &linkage.Spec
+	if !strings.HasPrefix(spec.TargetRef.Field, "spec.") {
+		return &linkageFault{"InvalidTarget", fmt.Sprintf("Target field %s is not a spec field", spec.TargetRef.Field)}, nil
+	}
+
+	if fault, err := r.checkBuiltIn(ctx, linkage.Namespace, &spec.TargetRef); fault != nil || err != nil {
+		return fault, err
+	}
+
+	_, source, fault, err := r.getPartContent(ctx, linkage.Namespace, &spec.SourceRef, "SourceNotFound")
+	if fault != nil || err != nil {
+		return fault, err
+	}
+	input, fault := readField(source, &spec.SourceRef)
+	if fault != nil {
+		return fault, nil
+	}
+	status.Input = input
+
+	target, content, fault, err := r.getPartContent(ctx, linkage.Namespace, &spec.TargetRef, "TargetNotFound")
+	if fault != nil || err != nil {
+		return fault, err
+	}
+	output, changed, fault := writeField(content, &spec.TargetRef, transfer(spec, input))
+	if fault != nil {
+		return fault, nil
+	}
+	status.Output = output
+	if !changed {
+		return nil, nil
+	}
+
+	original := target.DeepCopyObject()
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(content, target); err != nil {
+		return nil, err
+	}
+	if equality.Semantic.DeepEqual(original, target) {
+		// The converter drops fields the part doesn't have.
+		return &linkageFault{"FieldNotFound", fmt.Sprintf("%s %s has no field %s", spec.TargetRef.Kind, spec.TargetRef.Name, spec.TargetRef.Field)}, nil
+	}
+	if err := r.Update(ctx, target, client.FieldOwner(playv1alpha1.LinkageFieldManager)); err != nil {
+		if apierrors.IsInvalid(err) {
+			// The part's validation won't change its mind on a retry.
+			return &linkageFault{"TargetRejected", fmt.Sprintf("%s %s rejected field %s: %s", spec.TargetRef.Kind, spec.TargetRef.Name, spec.TargetRef.Field, err.Error())}, nil
+		}
+		return nil, err
+	}
+	log.FromContext(ctx).WithName("linkage").Info("Target has been set", "field", spec.TargetRef.Field, "value", output)
+	return nil, nil
+}
+
+// checkBuiltIn reports a fault if a built-in linkage already drives the
+// target field, which is the case when the control at the other end of the
+// built-in linkage exists.
+func (r *LinkageReconciler) checkBuiltIn(ctx context.Context, namespace string, ref *playv1alpha1.FieldReference) (*linkageFault, error) {
+	builtIn := drivenBy(ref)
+	if builtIn == nil {
+		return nil, nil
+	}
+	control := &playv1alpha1.FieldReference{Kind: builtIn.sourceKind, Name: ref.Name}
+	_, _, fault, err := r.getPartContent(ctx, namespace, control, "ControlNotFound")
+	if err != nil {
+		return nil, err
+	}
+	if fault != nil {
+		// No control, so nothing else drives the target.
+		return nil, nil
+	}
+	return &linkageFault{"TargetDrivenByLinkage", fmt.Sprintf("%s %s field %s is already driven by the %s linkage", ref.Kind, ref.Name, ref.Field, builtIn.sourceKind)}, nil
+}
+
+// getPartContent gets the part a field reference names, and returns it along
+// with its content in unstructured form.  A missing part is a fault with the
+// given reason.
+func (r *LinkageReconciler) getPartContent(ctx context.Context, namespace string, ref *playv1alpha1.FieldReference, notFoundReason string) (client.Object, map[string]interface{}, *linkageFault, error) {
+	obj, err := r.Scheme.New(playv1alpha1.GroupVersion.WithKind(ref.Kind))
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	part, ok := obj.(client.Object)
+	if !ok {
+		return nil, nil, nil, fmt.Errorf("kind %s is not an object", ref.Kind)
+	}
+
+	key := types.NamespacedName{Name: ref.Name, Namespace: namespace}
+	if err := r.Get(ctx, key, part); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil, &linkageFault{notFoundReason, fmt.Sprintf("%s %s was not found", ref.Kind, ref.Name)}, nil
+		}
+		return nil, nil, nil, err
+	}
+
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(part)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return part, content, nil, nil
+}
+
+// readField returns the value of a numeric field.  A field that is not set
+// reads as zero, and a boolean field reads as one when it is true.
+func readField(content map[string]interface{}, ref *playv1alpha1.FieldReference) (float64, *linkageFault) {
+	value, found, err := unstructured.NestedFieldNoCopy(content, strings.Split(ref.Field, ".")...)
+	if err != nil {
+		return 0, &linkageFault{"FieldNotNumeric", err.Error()}
+	}
+	if !found {
+		return 0, nil
+	}
+	switch v := value.(type) {
+	case int64:
+		return float64(v), nil
+	case float64:
+		return v, nil
+	case bool:
+		if v {
+			return 1, nil
+		}
+		return 0, nil
+	}
+	return 0, &linkageFault{"FieldNotNumeric", fmt.Sprintf("%s %s field %s is not a number", ref.Kind, ref.Name, ref.Field)}
+}
+
+// writeField sets a field to the given value, keeping the field's type.
+// Integer fields, and fields that aren't set yet, are rounded to the nearest
+// whole number, and boolean fields are true for any value other than zero.
+// It returns the value that was written and whether it changed the field.
+func writeField(content map[string]interface{}, ref *playv1alpha1.FieldReference, value float64) (float64, bool, *linkageFault) {
+	path := strings.Split(ref.Field, ".")
+	current, found, err := unstructured.NestedFieldNoCopy(content, path...)
+	if err != nil {
+		return 0, false, &linkageFault{"FieldNotNumeric", err.Error()}
+	}
+
+	var wanted interface{}
+	switch current.(type) {
+	case float64:
+		wanted = value
+	case bool:
+		wanted = value != 0
+		if value != 0 {
+			value = 1
+		}
+	case int64, nil:
+		value = math.Round(value)
+		wanted = int64(value)
+	default:
+		return 0, false, &linkageFault{"FieldNotNumeric", fmt.Sprintf("%s %s field %s is not a number", ref.Kind, ref.Name, ref.Field)}
+	}
+
+	if found && current == wanted {
+		return value, false, nil
+	}
+	if err := unstructured.SetNestedField(content, wanted, path...); err != nil {
+		return 0, false, &linkageFault{"FieldNotNumeric", err.Error()}
+	}
+	return value, true, nil
+}
+
+// transfer applies the linkage's rigging to a value read from the source.
+// Inside the dead band the target stays at zero, and outside it the source is
+// scaled by the gain, reversed if the linkage is rigged backwards, and held
+// within the limits.
+func transfer(spec *playv1alpha1.LinkageSpec, input float64) float64 {
+	gain := 1.0
+	if spec.Gain != nil {
+		gain = *spec.Gain
+	}
+	output := 0.0
+	if math.Abs(input) > spec.DeadBand {
+		output = input * gain
+	}
+	if spec.Reversed {
+		output = -output
+	}
+	if spec.Min != nil && output < *spec.Min {
+		output = *spec.Min
+	}
+	if spec.Max != nil && output > *spec.Max {
+		output = *spec.Max
+	}
+	return output
+}
+
+// linkageRefs are the index values for a linkage's source and target parts.
+func linkageRefs(obj client.Object) []string {
+	linkage, ok := obj.(*playv1alpha1.Linkage)
+	if !ok {
+		return nil
+	}
+	refs := []string{
+		linkage.Spec.SourceRef.Kind + "/" + linkage.Spec.SourceRef.Name,
+		linkage.Spec.TargetRef.Kind + "/" + linkage.Spec.TargetRef.Name,
+	}
+	if builtIn := drivenBy(&linkage.Spec.TargetRef); builtIn != nil {
+		// Notice the built-in linkage's control coming and going.
+		refs = append(refs, builtIn.sourceKind+"/"+linkage.Spec.TargetRef.Name)
+	}
+	return refs
+}
+
+// linkagesForPart maps a part to the linkages at either end of it, so a
+// linkage follows its source and notices a target that shows up late, goes
+// away, or has its spec changed out from under it.
+func (r *LinkageReconciler) linkagesForPart(obj client.Object) []reconcile.Request {
+	gvk, err := apiutil.GVKForObject(obj, r.Scheme)
+	if err != nil {
+		return nil
+	}
+
+	linkages := &playv1alpha1.LinkageList{}
+	if err := r.List(context.Background(), linkages, client.InNamespace(obj.GetNamespace()), client.MatchingFields{linkageRefIndex: gvk.Kind + "/" + obj.GetName()}); err != nil {
+		return nil
+	}
+
+	requests := []reconcile.Request{}
+	for _, linkage := range linkages.Items {
+		requests = append(requests, reconcile.Request{
+			NamespacedName: client.ObjectKeyFromObject(&linkage),
+		})
+	}
+	return requests
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *LinkageReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &playv1alpha1.Linkage{}, linkageRefIndex, linkageRefs); err != nil {
+		return err
+	}
+
+	b := ctrl.NewControllerManagedBy(mgr).
+		For(&playv1alpha1.Linkage{}, builder.WithPredicates(predicate.GenerationChangedPredicate{}))
+	for _, part := range linkableParts {
+		b = b.Watches(&source.Kind{Type: part}, handler.EnqueueRequestsFromMapFunc(r.linkagesForPart))
+	}
+	return b.Complete(r)
+}
@@ -0,0 +1,258 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	playv1alpha1 "github.com/roehrich-hpe/airplane-sim/api/v1alpha1"
+)
+
+var _ = Describe("Linkage Unit Tests", func() {
+
+	var (
+		key     types.NamespacedName
+		yoke    *playv1alpha1.Yoke
+		rudder  *playv1alpha1.Rudder
+		linkage *playv1alpha1.Linkage
+	)
+
+	BeforeEach(func() {
+		// The yoke's own roll linkage drives an aileron with the same
+		// name, so this rudder is only moved by the Linkage.
+		key = types.NamespacedName{
+			Name:      uuid.New().String()[0:8],
+			Namespace: corev1.NamespaceDefault,
+		}
+
+		yoke = &playv1alpha1.Yoke{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      key.Name,
+				Namespace: key.Namespace,
+			},
+		}
+		Expect(k8sClient.Create(context.TODO(), yoke)).To(Succeed())
+
+		rudder = &playv1alpha1.Rudder{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      key.Name + "-rudder",
+				Namespace: key.Namespace,
+			},
+		}
+
+		linkage = &playv1alpha1.Linkage{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      key.Name,
+				Namespace: key.Namespace,
+			},
+			Spec: playv1alpha1.LinkageSpec{
+				SourceRef: playv1alpha1.FieldReference{
+					Kind:  "Yoke",
+					Name:  yoke.Name,
+					Field: "status.rollLinkageTravel",
+				},
+				TargetRef: playv1alpha1.FieldReference{
+					Kind:  "Rudder",
+					Name:  rudder.Name,
+					Field: "spec.deflection",
+				},
+			},
+		}
+		gain := 0.25
+		linkage.Spec.Gain = &gain
+		Expect(k8sClient.Create(context.TODO(), linkage)).To(Succeed())
+	})
+
+	AfterEach(func() {
+		Expect(k8sClient.Delete(context.TODO(), linkage)).To(Succeed())
+		Expect(k8sClient.Delete(context.TODO(), yoke)).To(Succeed())
+		Expect(client.IgnoreNotFound(k8sClient.Delete(context.TODO(), rudder))).To(Succeed())
+
+		// Test env limitation: Wait until the cached object is gone.
+		expected := &playv1alpha1.Linkage{}
+		Eventually(func() error {
+			return k8sClient.Get(context.TODO(), key, expected)
+		}).ShouldNot(Succeed())
+	})
+
+	setRoll := func(roll int32) {
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(context.TODO(), key, yoke)).To(Succeed())
+			yoke.Spec.Roll = roll
+			g.Expect(k8sClient.Update(context.TODO(), yoke)).To(Succeed())
+		}).Should(Succeed())
+	}
+
+	updateLinkage := func(update func(spec *playv1alpha1.LinkageSpec)) {
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(context.TODO(), key, linkage)).To(Succeed())
+			update(&linkage.Spec)
+			g.Expect(k8sClient.Update(context.TODO(), linkage)).To(Succeed())
+		}).Should(Succeed())
+	}
+
+	expectDeflection := func(deflection int32) {
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(context.TODO(), client.ObjectKeyFromObject(rudder), rudder)).To(Succeed())
+			g.Expect(rudder.Spec.Deflection).To(HaveValue(Equal(deflection)))
+		}).Should(Succeed())
+	}
+
+	It("Reports a broken linkage until the target shows up", func() {
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(context.TODO(), key, linkage)).To(Succeed())
+			condition := meta.FindStatusCondition(linkage.Status.Conditions, playv1alpha1.ConditionLinkageBroken)
+			g.Expect(condition).ToNot(BeNil())
+			g.Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+			g.Expect(condition.Reason).To(Equal("TargetNotFound"))
+		}).Should(Succeed())
+
+		By("creating the rudder late")
+		Expect(k8sClient.Create(context.TODO(), rudder)).To(Succeed())
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(context.TODO(), key, linkage)).To(Succeed())
+			g.Expect(meta.IsStatusConditionFalse(linkage.Status.Conditions, playv1alpha1.ConditionLinkageBroken)).To(BeTrue())
+			g.Expect(meta.IsStatusConditionTrue(linkage.Status.Conditions, playv1alpha1.ConditionReady)).To(BeTrue())
+		}).WithTimeout(3 * time.Second).Should(Succeed())
+		expectDeflection(0)
+	})
+
+	It("Drives the target through the rigging", func() {
+		Expect(k8sClient.Create(context.TODO(), rudder)).To(Succeed())
+
+		By("rolling the yoke")
+		setRoll(40)
+		expectDeflection(10)
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(context.TODO(), key, linkage)).To(Succeed())
+			g.Expect(linkage.Status.Input).To(Equal(40.0))
+			g.Expect(linkage.Status.Output).To(Equal(10.0))
+		}).Should(Succeed())
+
+		By("rigging the linkage backwards")
+		updateLinkage(func(spec *playv1alpha1.LinkageSpec) { spec.Reversed = true })
+		expectDeflection(-10)
+
+		By("limiting the travel")
+		updateLinkage(func(spec *playv1alpha1.LinkageSpec) {
+			min := -5.0
+			spec.Min = &min
+		})
+		expectDeflection(-5)
+
+		By("widening the dead band past the input")
+		updateLinkage(func(spec *playv1alpha1.LinkageSpec) { spec.DeadBand = 50 })
+		expectDeflection(0)
+
+		By("disconnecting the linkage with a gain of zero")
+		updateLinkage(func(spec *playv1alpha1.LinkageSpec) {
+			spec.DeadBand = 0
+			gain := 0.0
+			spec.Gain = &gain
+		})
+		expectDeflection(0)
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(context.TODO(), key, linkage)).To(Succeed())
+			g.Expect(linkage.Spec.Gain).To(HaveValue(BeZero()))
+			g.Expect(linkage.Status.Output).To(BeZero())
+		}).Should(Succeed())
+
+		By("correcting a target that drifted")
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(context.TODO(), client.ObjectKeyFromObject(rudder), rudder)).To(Succeed())
+			deflection := int32(20)
+			rudder.Spec.Deflection = &deflection
+			g.Expect(k8sClient.Update(context.TODO(), rudder)).To(Succeed())
+		}).Should(Succeed())
+		expectDeflection(0)
+	})
+
+	It("Reports a broken linkage when the pedal linkage drives the target", func() {
+		Expect(k8sClient.Create(context.TODO(), rudder)).To(Succeed())
+		setRoll(40)
+		expectDeflection(10)
+
+		By("adding pedals that drive the same rudder")
+		pedals := &playv1alpha1.Pedals{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      rudder.Name,
+				Namespace: key.Namespace,
+			},
+		}
+		Expect(k8sClient.Create(context.TODO(), pedals)).To(Succeed())
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(context.TODO(), key, linkage)).To(Succeed())
+			condition := meta.FindStatusCondition(linkage.Status.Conditions, playv1alpha1.ConditionLinkageBroken)
+			g.Expect(condition).ToNot(BeNil())
+			g.Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+			g.Expect(condition.Reason).To(Equal("TargetDrivenByLinkage"))
+		}).Should(Succeed())
+		expectDeflection(0)
+
+		By("removing the pedals")
+		Expect(k8sClient.Delete(context.TODO(), pedals)).To(Succeed())
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(context.TODO(), key, linkage)).To(Succeed())
+			g.Expect(meta.IsStatusConditionFalse(linkage.Status.Conditions, playv1alpha1.ConditionLinkageBroken)).To(BeTrue())
+		}).Should(Succeed())
+		expectDeflection(10)
+	})
+
+	It("Reports a broken linkage when the target rejects the value", func() {
+		pedals := &playv1alpha1.Pedals{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      key.Name + "-pedals",
+				Namespace: key.Namespace,
+			},
+		}
+		Expect(k8sClient.Create(context.TODO(), pedals)).To(Succeed())
+		defer func() {
+			Expect(k8sClient.Delete(context.TODO(), pedals)).To(Succeed())
+		}()
+
+		By("driving the pedal travel past its limit")
+		updateLinkage(func(spec *playv1alpha1.LinkageSpec) {
+			spec.TargetRef = playv1alpha1.FieldReference{
+				Kind:  "Pedals",
+				Name:  pedals.Name,
+				Field: "spec.travel",
+			}
+			gain := 10.0
+			spec.Gain = &gain
+		})
+		setRoll(40)
+
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(context.TODO(), key, linkage)).To(Succeed())
+			condition := meta.FindStatusCondition(linkage.Status.Conditions, playv1alpha1.ConditionLinkageBroken)
+			g.Expect(condition).ToNot(BeNil())
+			g.Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+			g.Expect(condition.Reason).To(Equal("TargetRejected"))
+		}).Should(Succeed())
+	})
+})
@@ -0,0 +1,183 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	playv1alpha1 "github.com/roehrich-hpe/airplane-sim/api/v1alpha1"
+)
+
+// The per-airplane metrics are served alongside the controller-runtime
+// metrics, and are labeled by the airplane's tail number and namespace.
+var (
+	rudderCommandedMetric = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "airplane",
+		Subsystem: "rudder",
+		Name:      "commanded_deflection_degrees",
+		Help:      "Rudder deflection the rudder is moving toward, in degrees.",
+	}, []string{"tail_number", "namespace"})
+
+	rudderDeflectionMetric = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "airplane",
+		Subsystem: "rudder",
+		Name:      "deflection_degrees",
+		Help:      "Actual rudder deflection, in degrees.",
+	}, []string{"tail_number", "namespace"})
+
+	pedalTravelMetric = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "airplane",
+		Subsystem: "pedals",
+		Name:      "travel_percent",
+		Help:      "Rudder pedal input, in percent of full travel.  Negative is left.",
+	}, []string{"tail_number", "namespace"})
+
+	linkageLagMetric = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "airplane",
+		Subsystem: "pedal_linkage",
+		Name:      "lag_seconds",
+		Help:      "Time from a change to the pedals until the rudder reaches the commanded deflection.",
+		Buckets:   []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60},
+	}, []string{"tail_number", "namespace"})
+
+	conflictsRetriedMetric = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "airplane",
+		Name:      "conflicts_retried_total",
+		Help:      "Update conflicts that were retried, by controller.",
+	}, []string{"controller", "tail_number", "namespace"})
+
+	airplaneReadyMetric = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "airplane",
+		Name:      "ready",
+		Help:      "Whether the airplane is ready to fly.",
+	}, []string{"tail_number", "namespace"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(
+		rudderCommandedMetric,
+		rudderDeflectionMetric,
+		pedalTravelMetric,
+		linkageLagMetric,
+		conflictsRetriedMetric,
+		airplaneReadyMetric,
+	)
+}
+
+// airplaneLabels returns the metric labels for an airplane.
+func airplaneLabels(airplane *playv1alpha1.Airplane) prometheus.Labels {
+	return prometheus.Labels{"tail_number": airplane.Spec.TailNumber, "namespace": airplane.GetNamespace()}
+}
+
+// partLabels returns the metric labels for the airplane that a part belongs
+// to.  A part that doesn't belong to an airplane has no labels, and isn't
+// measured.
+func partLabels(ctx context.Context, c client.Reader, part client.Object) (prometheus.Labels, bool) {
+	airplane := partAirplane(ctx, c, part)
+	if airplane == nil {
+		return nil, false
+	}
+	return airplaneLabels(airplane), true
+}
+
+// forgetAirplane drops the series for a tail number that is no longer in use,
+// either because the airplane is gone or because it was re-registered.
+func forgetAirplane(tailNumber, namespace string) {
+	labels := prometheus.Labels{"tail_number": tailNumber, "namespace": namespace}
+	rudderCommandedMetric.Delete(labels)
+	rudderDeflectionMetric.Delete(labels)
+	pedalTravelMetric.Delete(labels)
+	linkageLagMetric.Delete(labels)
+	airplaneReadyMetric.Delete(labels)
+	for _, controller := range []string{"pedallinkage", "rudder"} {
+		conflictsRetriedMetric.Delete(prometheus.Labels{"controller": controller, "tail_number": tailNumber, "namespace": namespace})
+	}
+	linkageTimer.forget(labels)
+}
+
+// countConflict notes a conflict that the controller is about to retry.
+func countConflict(controller string, labels prometheus.Labels, ok bool) {
+	if !ok {
+		return
+	}
+	conflictsRetriedMetric.With(prometheus.Labels{
+		"controller":  controller,
+		"tail_number": labels["tail_number"],
+		"namespace":   labels["namespace"],
+	}).Inc()
+}
+
+// linkageTimer times the pedal linkage, from the linkage seeing a change to
+// the pedals until the rudder reaches the deflection it was given.  The
+// pedal linkage starts the timer and the rudder controller stops it, and
+// both run in the manager, so the pending changes are kept in memory, by
+// tail number and namespace.  A change that is still pending when the pedals
+// change again is replaced.
+var linkageTimer = &lagTimer{pending: map[lagKey]pendingMove{}}
+
+type lagKey struct {
+	tailNumber string
+	namespace  string
+}
+
+type pendingMove struct {
+	target int32
+	since  time.Time
+}
+
+type lagTimer struct {
+	sync.Mutex
+	pending map[lagKey]pendingMove
+}
+
+func newLagKey(labels prometheus.Labels) lagKey {
+	return lagKey{tailNumber: labels["tail_number"], namespace: labels["namespace"]}
+}
+
+// start notes that the airplane's rudder was commanded to a new deflection.
+func (t *lagTimer) start(labels prometheus.Labels, target int32, now time.Time) {
+	t.Lock()
+	defer t.Unlock()
+	t.pending[newLagKey(labels)] = pendingMove{target: target, since: now}
+}
+
+// stop returns how long the rudder took to reach its commanded deflection,
+// if the rudder was waited on and has arrived.
+func (t *lagTimer) stop(labels prometheus.Labels, deflection int32, now time.Time) (time.Duration, bool) {
+	t.Lock()
+	defer t.Unlock()
+	key := newLagKey(labels)
+	move, ok := t.pending[key]
+	if !ok || move.target != deflection {
+		return 0, false
+	}
+	delete(t.pending, key)
+	return now.Sub(move.since), true
+}
+
+// forget drops the pending change for an airplane that is going away.
+func (t *lagTimer) forget(labels prometheus.Labels) {
+	t.Lock()
+	defer t.Unlock()
+	delete(t.pending, newLagKey(labels))
+}
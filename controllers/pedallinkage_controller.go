@@ -20,11 +20,20 @@ import (
 	"context"
 	"time"
 
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	playv1alpha1 "github.com/roehrich-hpe/airplane-sim/api/v1alpha1"
 )
@@ -32,12 +41,14 @@ import (
 // PedalLinkageReconciler reconciles a PedalLinkage object
 type PedalLinkageReconciler struct {
 	client.Client
-	Scheme *runtime.Scheme
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
 }
 
 //+kubebuilder:rbac:groups=play.github.com,resources=pedals,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=play.github.com,resources=pedals/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=play.github.com,resources=pedals/finalizers,verbs=update
+//+kubebuilder:rbac:groups="",resources=events,verbs=create;patch
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -56,29 +67,16 @@ func (r *PedalLinkageReconciler) Reconcile(ctx context.Context, req ctrl.Request
 		return ctrl.Result{}, client.IgnoreNotFound(err)
 	}
 
-	var positionWanted string
-	switch pedals.Spec.Pressed {
-	case "none":
-		if pedals.Status.LinkagePosition != "neutral" {
-			positionWanted = "neutral"
-		}
-	default:
-		if pedals.Status.LinkagePosition != pedals.Spec.Pressed {
-			positionWanted = pedals.Spec.Pressed
-		}
-	}
+	travel := pedalsTravel(&pedals.Spec)
+	position := linkagePosition(travel)
+	status := pedals.Status.DeepCopy()
+	status.LinkagePosition = position
+	status.LinkageTravel = travel
+	status.ObservedGeneration = pedals.Generation
 
-	if len(positionWanted) > 0 {
-		log.Info("Resetting position")
-		pedals.Status.LinkagePosition = positionWanted
-		if err := r.Status().Update(ctx, pedals); err != nil {
-			if apierrors.IsConflict(err) {
-				log.Info("Conflict while setting position")
-				return ctrl.Result{Requeue: true}, nil
-			}
-			log.Error(err, "Error while setting position")
-			return ctrl.Result{}, err
-		}
+	labels, measured := partLabels(ctx, r.Client, pedals)
+	if measured {
+		pedalTravelMetric.With(labels).Set(float64(travel))
 	}
 
 	// Get the rudder, move it if necessary.
@@ -86,28 +84,111 @@ func (r *PedalLinkageReconciler) Reconcile(ctx context.Context, req ctrl.Request
 	// Rudder and Pedals have the same name.
 	rudderKey := req.NamespacedName
 	if err := r.Get(ctx, rudderKey, rudder); err != nil {
-		log.Error(err, "Did not find rudder")
-		return ctrl.Result{RequeueAfter: time.Second * 10}, nil
+		if !apierrors.IsNotFound(err) {
+			log.Error(err, "Unable to get rudder")
+			return ctrl.Result{}, err
+		}
+		// Nothing to do until the rudder shows up, and the watch on
+		// rudders will tell us when it does.
+		if !meta.IsStatusConditionTrue(status.Conditions, playv1alpha1.ConditionLinkageBroken) {
+			log.Info("Did not find rudder, linkage is broken")
+		}
+		setCondition(&status.Conditions, pedals.Generation, playv1alpha1.ConditionLinkageBroken, true, "RudderNotFound", "The pedal linkage is not connected to a rudder")
+		setCondition(&status.Conditions, pedals.Generation, playv1alpha1.ConditionControlsLinked, false, "RudderNotFound", "The pedal linkage is not connected to a rudder")
+		setCondition(&status.Conditions, pedals.Generation, playv1alpha1.ConditionReady, false, "RudderNotFound", "")
+	} else {
+		deflection := rudderDeflection(travel, &rudder.Spec)
+		if rudder.Spec.Position != position || rudder.Spec.Deflection == nil || *rudder.Spec.Deflection != deflection {
+			rudder.Spec.Position = position
+			rudder.Spec.Deflection = &deflection
+			if err := r.Update(ctx, rudder, client.FieldOwner(playv1alpha1.LinkageFieldManager)); err != nil {
+				if apierrors.IsConflict(err) {
+					log.Info("Conflict on rudder")
+					recordPartEvent(ctx, r.Client, r.Recorder, pedals, corev1.EventTypeNormal, playv1alpha1.EventLinkageConflict, "Rudder %s changed while the linkage was moving it, trying again", rudder.GetName())
+					countConflict("pedallinkage", labels, measured)
+					return ctrl.Result{Requeue: true}, nil
+				}
+				log.Error(err, "Error on rudder")
+				return ctrl.Result{}, err
+			}
+			log.Info("rudder has been set")
+			if measured {
+				linkageTimer.start(labels, deflection, time.Now())
+			}
+		}
+		setCondition(&status.Conditions, pedals.Generation, playv1alpha1.ConditionLinkageBroken, false, "Linked", "")
+		setCondition(&status.Conditions, pedals.Generation, playv1alpha1.ConditionControlsLinked, true, "Linked", "")
+		setCondition(&status.Conditions, pedals.Generation, playv1alpha1.ConditionReady, true, "RudderSet", "")
 	}
 
-	if rudder.Spec.Position != pedals.Status.LinkagePosition {
-		rudder.Spec.Position = pedals.Status.LinkagePosition
-		if err := r.Update(ctx, rudder); err != nil {
+	if !equality.Semantic.DeepEqual(status, &pedals.Status) {
+		if pedals.Status.LinkagePosition != position || pedals.Status.LinkageTravel != travel {
+			log.Info("Resetting position")
+		}
+		pedals.Status = *status
+		if err := r.Status().Update(ctx, pedals); err != nil {
 			if apierrors.IsConflict(err) {
-				log.Info("Conflict on rudder")
+				log.Info("Conflict while setting position")
+				countConflict("pedallinkage", labels, measured)
 				return ctrl.Result{Requeue: true}, nil
 			}
-			log.Error(err, "Error on rudder")
+			log.Error(err, "Error while setting position")
 			return ctrl.Result{}, err
 		}
-		log.Info("rudder has been set")
 	}
+
 	return ctrl.Result{}, nil
 }
 
-// SetupWithManager sets up the controller with the Manager.
+// pedalsTravel returns the pedal travel, in percent, that the spec asks for.
+// An explicit travel wins over the pressed pedal.
+func pedalsTravel(spec *playv1alpha1.PedalsSpec) int32 {
+	if spec.Travel != nil {
+		return clamp(*spec.Travel, -100, 100)
+	}
+	switch spec.Pressed {
+	case "left":
+		return -100
+	case "right":
+		return 100
+	}
+	return 0
+}
+
+// linkagePosition describes the linkage travel as one of the coarse
+// positions.
+func linkagePosition(travel int32) string {
+	switch {
+	case travel < 0:
+		return "left"
+	case travel > 0:
+		return "right"
+	}
+	return "neutral"
+}
+
+// rudderDeflection maps the linkage travel onto the rudder's travel limits,
+// so full pedal travel gives full rudder deflection on the same side.
+func rudderDeflection(travel int32, spec *playv1alpha1.RudderSpec) int32 {
+	minDeflection, maxDeflection := spec.Limits()
+	return scaleTravel(travel, minDeflection, maxDeflection)
+}
+
+// pedalsForRudder maps a rudder to the pedals that drive it, so the linkage
+// notices a rudder that shows up late, goes away, or has its spec changed out
+// from under it.
+func (r *PedalLinkageReconciler) pedalsForRudder(obj client.Object) []reconcile.Request {
+	// Rudder and Pedals have the same name.
+	return []reconcile.Request{{
+		NamespacedName: client.ObjectKeyFromObject(obj),
+	}}
+}
+
+// SetupWithManager sets up the controller with the Manager.  Only changes to
+// the rudder's spec matter to the linkage, not the rudder moving.
 func (r *PedalLinkageReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&playv1alpha1.Pedals{}).
+		Watches(&source.Kind{Type: &playv1alpha1.Rudder{}}, handler.EnqueueRequestsFromMapFunc(r.pedalsForRudder), builder.WithPredicates(predicate.GenerationChangedPredicate{})).
 		Complete(r)
 }
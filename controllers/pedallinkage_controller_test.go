@@ -18,14 +18,17 @@ package controllers
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	playv1alpha1 "github.com/roehrich-hpe/airplane-sim/api/v1alpha1"
 )
@@ -132,4 +135,101 @@ var _ = Describe("PedalLinkage Unit Tests", func() {
 			g.Expect(rudderExpected.Status.Position).To(Equal(linkageWanted))
 		}).Should(Succeed())
 	})
+
+	It("Moves the rudder in proportion to pedal travel", func() {
+		By("pressing the left pedal part way")
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(context.TODO(), key, pedals)).To(Succeed())
+			travel := int32(-40)
+			pedals.Spec.Travel = &travel
+			g.Expect(k8sClient.Update(context.TODO(), pedals)).To(Succeed())
+		}).Should(Succeed())
+
+		By("watching the linkage move")
+		expected := &playv1alpha1.Pedals{}
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(context.TODO(), key, expected)).To(Succeed())
+			g.Expect(expected.Status.LinkageTravel).To(Equal(int32(-40)))
+			g.Expect(expected.Status.LinkagePosition).To(Equal("left"))
+		}).Should(Succeed())
+
+		By("checking that the rudder moved part way")
+		rudderExpected := &playv1alpha1.Rudder{}
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(context.TODO(), key, rudderExpected)).To(Succeed())
+			wanted := *rudderExpected.Spec.MinDeflection * 40 / 100
+			g.Expect(rudderExpected.Spec.Deflection).To(HaveValue(Equal(wanted)))
+			g.Expect(rudderExpected.Status.Deflection).To(Equal(wanted))
+		}).Should(Succeed())
+	})
+
+	It("Reports the linkage to the rudder", func() {
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(context.TODO(), key, pedals)).To(Succeed())
+			g.Expect(pedals.Status.ObservedGeneration).To(Equal(pedals.Generation))
+			g.Expect(meta.IsStatusConditionTrue(pedals.Status.Conditions, playv1alpha1.ConditionControlsLinked)).To(BeTrue())
+			g.Expect(meta.IsStatusConditionFalse(pedals.Status.Conditions, playv1alpha1.ConditionLinkageBroken)).To(BeTrue())
+			g.Expect(meta.IsStatusConditionTrue(pedals.Status.Conditions, playv1alpha1.ConditionReady)).To(BeTrue())
+		}).Should(Succeed())
+
+		By("checking pedals without a rudder report a broken linkage")
+		unlinked := &playv1alpha1.Pedals{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      uuid.New().String()[0:8],
+				Namespace: key.Namespace,
+			},
+		}
+		Expect(k8sClient.Create(context.TODO(), unlinked)).To(Succeed())
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(context.TODO(), client.ObjectKeyFromObject(unlinked), unlinked)).To(Succeed())
+			condition := meta.FindStatusCondition(unlinked.Status.Conditions, playv1alpha1.ConditionLinkageBroken)
+			g.Expect(condition).ToNot(BeNil())
+			g.Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+			g.Expect(condition.Reason).To(Equal("RudderNotFound"))
+			g.Expect(meta.IsStatusConditionFalse(unlinked.Status.Conditions, playv1alpha1.ConditionControlsLinked)).To(BeTrue())
+		}).Should(Succeed())
+
+		By("creating the rudder late")
+		lateRudder := &playv1alpha1.Rudder{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      unlinked.Name,
+				Namespace: unlinked.Namespace,
+			},
+		}
+		Expect(k8sClient.Create(context.TODO(), lateRudder)).To(Succeed())
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(context.TODO(), client.ObjectKeyFromObject(unlinked), unlinked)).To(Succeed())
+			g.Expect(meta.IsStatusConditionFalse(unlinked.Status.Conditions, playv1alpha1.ConditionLinkageBroken)).To(BeTrue())
+			g.Expect(meta.IsStatusConditionTrue(unlinked.Status.Conditions, playv1alpha1.ConditionControlsLinked)).To(BeTrue())
+		}).WithTimeout(3 * time.Second).Should(Succeed())
+
+		By("removing the rudder")
+		Expect(k8sClient.Delete(context.TODO(), lateRudder)).To(Succeed())
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(context.TODO(), client.ObjectKeyFromObject(unlinked), unlinked)).To(Succeed())
+			g.Expect(meta.IsStatusConditionTrue(unlinked.Status.Conditions, playv1alpha1.ConditionLinkageBroken)).To(BeTrue())
+		}).WithTimeout(3 * time.Second).Should(Succeed())
+		Expect(k8sClient.Delete(context.TODO(), unlinked)).To(Succeed())
+	})
+
+	It("Corrects a rudder that drifted from the pedals", func() {
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(context.TODO(), key, rudder)).To(Succeed())
+			g.Expect(rudder.Spec.Position).To(Equal("neutral"))
+		}).Should(Succeed())
+
+		By("moving the rudder behind the linkage's back")
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(context.TODO(), key, rudder)).To(Succeed())
+			rudder.Spec.Position = "left"
+			rudder.Spec.Deflection = nil
+			g.Expect(k8sClient.Update(context.TODO(), rudder)).To(Succeed())
+		}).Should(Succeed())
+
+		By("watching the linkage put it back")
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(context.TODO(), key, rudder)).To(Succeed())
+			g.Expect(rudder.Spec.Position).To(Equal("neutral"))
+		}).WithTimeout(3 * time.Second).Should(Succeed())
+	})
 })
@@ -0,0 +1,101 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	playv1alpha1 "github.com/roehrich-hpe/airplane-sim/api/v1alpha1"
+)
+
+// PitchLinkageReconciler reconciles the pitch linkage between a Yoke and
+// its Elevator
+type PitchLinkageReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+}
+
+//+kubebuilder:rbac:groups=play.github.com,resources=yokes,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=play.github.com,resources=yokes/status,verbs=get;update;patch
+
+// Reconcile moves the pitch linkage to follow the yoke, and moves the
+// elevator to follow the linkage.
+//
+// For more details, check Reconcile and its Result here:
+// - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.12.2/pkg/reconcile
+func (r *PitchLinkageReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := log.FromContext(ctx).WithName("pitchlinkage")
+
+	yoke := &playv1alpha1.Yoke{}
+	if err := r.Get(ctx, req.NamespacedName, yoke); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	travel := clamp(yoke.Spec.Pitch, -100, 100)
+	if yoke.Status.PitchLinkageTravel != travel {
+		log.Info("Resetting pitch linkage")
+		yoke.Status.PitchLinkageTravel = travel
+		if err := r.Status().Update(ctx, yoke); err != nil {
+			if apierrors.IsConflict(err) {
+				log.Info("Conflict while setting pitch linkage")
+				return ctrl.Result{Requeue: true}, nil
+			}
+			log.Error(err, "Error while setting pitch linkage")
+			return ctrl.Result{}, err
+		}
+	}
+
+	// Get the elevator, move it if necessary.
+	elevator := &playv1alpha1.Elevator{}
+	// Elevator and Yoke have the same name.
+	elevatorKey := req.NamespacedName
+	if err := r.Get(ctx, elevatorKey, elevator); err != nil {
+		log.Error(err, "Did not find elevator")
+		return ctrl.Result{RequeueAfter: time.Second * 10}, nil
+	}
+
+	deflection := scaleTravel(travel, elevator.Spec.MinDeflection, elevator.Spec.MaxDeflection)
+	if elevator.Spec.Deflection != deflection {
+		elevator.Spec.Deflection = deflection
+		if err := r.Update(ctx, elevator, client.FieldOwner(playv1alpha1.LinkageFieldManager)); err != nil {
+			if apierrors.IsConflict(err) {
+				log.Info("Conflict on elevator")
+				return ctrl.Result{Requeue: true}, nil
+			}
+			log.Error(err, "Error on elevator")
+			return ctrl.Result{}, err
+		}
+		log.Info("elevator has been set")
+	}
+	return ctrl.Result{}, nil
+}
+
+// SetupWithManager sets up the controller with the Manager.  The roll
+// linkage also watches the yoke, so this controller needs its own name.
+func (r *PitchLinkageReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("pitchlinkage").
+		For(&playv1alpha1.Yoke{}).
+		Complete(r)
+}
@@ -0,0 +1,157 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"reflect"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	playv1alpha1 "github.com/roehrich-hpe/airplane-sim/api/v1alpha1"
+)
+
+// Claim the registration for the airplane's tail number, so no other
+// airplane in the cluster can use it.  A registration whose airplane is gone
+// is taken over.  Once the airplane holds its new registration, the one for
+// the tail number it had before is released.
+func (r *AirplaneReconciler) claimRegistration(ctx context.Context, airplane *playv1alpha1.Airplane) error {
+	log := r.Log.WithName("registration")
+
+	tailNumber := airplane.Spec.TailNumber
+	airplaneRef := corev1.ObjectReference{
+		APIVersion: playv1alpha1.GroupVersion.String(),
+		Kind:       reflect.TypeOf(*airplane).Name(),
+		Namespace:  airplane.GetNamespace(),
+		Name:       airplane.GetName(),
+		UID:        airplane.GetUID(),
+	}
+
+	registration := &playv1alpha1.Registration{}
+	key := client.ObjectKey{Name: strings.ToLower(tailNumber)}
+	if err := r.Get(ctx, key, registration); err != nil {
+		if !errors.IsNotFound(err) {
+			log.Error(err, "Unable to get registration", "tailNumber", tailNumber)
+			return err
+		}
+
+		registration = &playv1alpha1.Registration{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: key.Name,
+			},
+			Spec: playv1alpha1.RegistrationSpec{
+				TailNumber:  tailNumber,
+				AirplaneRef: airplaneRef,
+			},
+		}
+		if err := r.Create(ctx, registration); err != nil {
+			// Another airplane may have got there first.  We'll
+			// go around again and find out.
+			log.Error(err, "Unable to claim registration", "tailNumber", tailNumber)
+			return err
+		}
+		log.Info("Claimed registration", "tailNumber", tailNumber)
+	} else if registration.Spec.AirplaneRef.UID != airplane.GetUID() {
+		held, err := r.registrationHeld(ctx, registration)
+		if err != nil {
+			return err
+		}
+		if held {
+			return &partConflict{
+				reason: "RegistrationConflict",
+				kind:   reflect.TypeOf(*registration).Name(),
+				name:   registration.GetName(),
+				holder: "another airplane",
+			}
+		}
+
+		registration.Spec.AirplaneRef = airplaneRef
+		if err := r.Update(ctx, registration); err != nil {
+			log.Error(err, "Unable to take over registration", "tailNumber", tailNumber)
+			return err
+		}
+		log.Info("Took over stale registration", "tailNumber", tailNumber)
+	}
+
+	if previous := airplane.Status.TailNumber; len(previous) > 0 && previous != tailNumber {
+		return r.releaseRegistration(ctx, airplane, previous)
+	}
+	return nil
+}
+
+// registrationHeld reports whether the airplane that a registration names
+// still exists.
+func (r *AirplaneReconciler) registrationHeld(ctx context.Context, registration *playv1alpha1.Registration) (bool, error) {
+	holderRef := registration.Spec.AirplaneRef
+	holder := &playv1alpha1.Airplane{}
+	if err := r.Get(ctx, client.ObjectKey{Namespace: holderRef.Namespace, Name: holderRef.Name}, holder); err != nil {
+		if errors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return holder.GetUID() == holderRef.UID, nil
+}
+
+// Release the registration for a tail number, if the airplane holds it.
+func (r *AirplaneReconciler) releaseRegistration(ctx context.Context, airplane *playv1alpha1.Airplane, tailNumber string) error {
+	log := r.Log.WithName("registration")
+
+	registration := &playv1alpha1.Registration{}
+	if err := r.Get(ctx, client.ObjectKey{Name: strings.ToLower(tailNumber)}, registration); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	if registration.Spec.AirplaneRef.UID != airplane.GetUID() {
+		return nil
+	}
+	uid := registration.GetUID()
+	if err := r.Delete(ctx, registration, client.Preconditions{UID: &uid}); err != nil && !errors.IsNotFound(err) {
+		log.Error(err, "Unable to release registration", "tailNumber", tailNumber)
+		return err
+	}
+	log.Info("Released registration", "tailNumber", tailNumber)
+	return nil
+}
+
+// airplanesForRegistration maps a registration to the airplanes that want
+// its tail number, so an airplane that was waiting for it can claim it once
+// it is released.
+func (r *AirplaneReconciler) airplanesForRegistration(obj client.Object) []reconcile.Request {
+	registration, ok := obj.(*playv1alpha1.Registration)
+	if !ok {
+		return nil
+	}
+	airplanes := &playv1alpha1.AirplaneList{}
+	if err := r.List(context.Background(), airplanes); err != nil {
+		return nil
+	}
+
+	requests := []reconcile.Request{}
+	for _, airplane := range airplanes.Items {
+		if airplane.Spec.TailNumber == registration.Spec.TailNumber {
+			requests = append(requests, reconcile.Request{
+				NamespacedName: client.ObjectKeyFromObject(&airplane),
+			})
+		}
+	}
+	return requests
+}
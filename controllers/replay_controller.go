@@ -0,0 +1,394 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	playv1alpha1 "github.com/roehrich-hpe/airplane-sim/api/v1alpha1"
+	"github.com/roehrich-hpe/airplane-sim/pkg/flightdata"
+)
+
+// maxReplayDivergences is how many divergences a replay lists in its status.
+// The rest are only counted.
+const maxReplayDivergences = 10
+
+// ReplayReconciler reconciles a Replay object
+type ReplayReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+
+	// APIReader reads the timelines straight from the API server, so the
+	// manager doesn't have to cache every ConfigMap in the cluster.
+	APIReader client.Reader
+}
+
+//+kubebuilder:rbac:groups=play.github.com,resources=replays,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=play.github.com,resources=replays/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=play.github.com,resources=replays/finalizers,verbs=update
+//+kubebuilder:rbac:groups=play.github.com,resources=pedals,verbs=get;list;watch;update
+//+kubebuilder:rbac:groups=play.github.com,resources=yokes,verbs=get;list;watch;update
+//+kubebuilder:rbac:groups=play.github.com,resources=trimwheels,verbs=get;list;watch;update
+//+kubebuilder:rbac:groups=play.github.com,resources=flaplevers,verbs=get;list;watch;update
+//+kubebuilder:rbac:groups=play.github.com,resources=gearlevers,verbs=get;list;watch;update
+//+kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch
+
+// Reconcile plays the timeline back on the airplane.  Each sample's inputs
+// to the pedals, yoke, trim wheel, flap lever and gear lever are applied
+// when the simulation clock reaches the sample's offset from the start of
+// the timeline, and before they are applied the rudder is compared against
+// the deflection the sample recorded.  A replay that is
+// running late only compares the latest sample that is due, because the
+// rudder has already moved on from the earlier ones.
+//
+// For more details, check Reconcile and its Result here:
+// - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.12.2/pkg/reconcile
+func (r *ReplayReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := log.FromContext(ctx).WithName("replay")
+
+	replay := &playv1alpha1.Replay{}
+	if err := r.Get(ctx, req.NamespacedName, replay); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	if replay.Status.Phase == playv1alpha1.ReplayFinished {
+		return ctrl.Result{}, nil
+	}
+
+	status := replay.Status.DeepCopy()
+	status.ObservedGeneration = replay.Generation
+	if len(status.Phase) == 0 {
+		status.Phase = playv1alpha1.ReplayPending
+	}
+
+	samples, err := r.readTimeline(ctx, replay)
+	if err != nil {
+		if _, ok := err.(*timelineError); !ok {
+			log.Error(err, "Unable to read timeline")
+			return ctrl.Result{}, err
+		}
+		log.Info("Timeline is not ready", "reason", err.Error())
+		setCondition(&status.Conditions, replay.Generation, playv1alpha1.ConditionReady, false, "TimelineNotReady", err.Error())
+		return r.updateStatus(ctx, replay, status, ctrl.Result{RequeueAfter: time.Second * 10})
+	}
+	status.Samples = int32(len(samples))
+
+	controls, err := r.getControls(ctx, replay)
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			log.Error(err, "Unable to get airplane")
+			return ctrl.Result{}, err
+		}
+		// The watch on airplanes will tell us when it's ready.
+		setCondition(&status.Conditions, replay.Generation, playv1alpha1.ConditionReady, false, "AirplaneNotReady", fmt.Sprintf("Airplane %q does not have its pedals and rudder", replay.Spec.AirplaneRef.Name))
+		return r.updateStatus(ctx, replay, status, ctrl.Result{})
+	}
+
+	clock, err := readClock(ctx, r.Client, req.Namespace)
+	if err != nil {
+		log.Error(err, "Unable to read simulation clock")
+		return ctrl.Result{}, err
+	}
+	if status.StartTime == nil {
+		started := metav1.NewMicroTime(clock.now)
+		status.StartTime = &started
+		status.Phase = playv1alpha1.ReplayPlaying
+		log.Info("Starting replay", "airplane", replay.Spec.AirplaneRef.Name, "samples", len(samples))
+	}
+	elapsed := clock.now.Sub(status.StartTime.Time)
+	origin := samples[0].Time
+
+	next := int(status.NextSample)
+	due := next
+	for due < len(samples) && samples[due].Time.Sub(origin) <= elapsed {
+		due++
+	}
+	if due > next {
+		latest := samples[due-1]
+		compareRudder(status, latest.Time.Sub(origin), latest.RudderDeflection, controls.rudder.Status.Deflection, replay.Spec.Tolerance)
+
+		if err := r.applyInputs(ctx, controls, &latest); err != nil {
+			if apierrors.IsConflict(err) {
+				log.Info("Conflict while setting controls")
+				return ctrl.Result{Requeue: true}, nil
+			}
+			log.Error(err, "Unable to set controls")
+			return ctrl.Result{}, err
+		}
+		status.NextSample = int32(due)
+	}
+
+	result := ctrl.Result{}
+	if int(status.NextSample) < len(samples) {
+		setCondition(&status.Conditions, replay.Generation, playv1alpha1.ConditionReady, false, "Playing", "")
+		wait := samples[status.NextSample].Time.Sub(origin) - elapsed
+		result.RequeueAfter = clock.wallTime(wait)
+	} else {
+		status.Phase = playv1alpha1.ReplayFinished
+		setCondition(&status.Conditions, replay.Generation, playv1alpha1.ConditionReady, true, "Finished", "")
+		log.Info("Finished replay", "compared", status.Compared, "diverged", status.DivergenceCount)
+	}
+	if status.DivergenceCount > 0 {
+		first := status.Divergences[0]
+		setCondition(&status.Conditions, replay.Generation, playv1alpha1.ConditionDiverged, true, "RudderDiverged",
+			fmt.Sprintf("The rudder was at %d degrees instead of %d at %s", first.Actual, first.Expected, first.Offset.Duration))
+	} else {
+		setCondition(&status.Conditions, replay.Generation, playv1alpha1.ConditionDiverged, false, "FollowingRecording", "")
+	}
+
+	return r.updateStatus(ctx, replay, status, result)
+}
+
+// compareRudder checks the rudder against the deflection that a sample
+// recorded, and notes any divergence in the status.
+func compareRudder(status *playv1alpha1.ReplayStatus, offset time.Duration, expected, actual, tolerance int32) {
+	status.Compared++
+	off := actual - expected
+	if off < 0 {
+		off = -off
+	}
+	if off <= tolerance {
+		return
+	}
+	status.DivergenceCount++
+	if off > status.MaxDivergence {
+		status.MaxDivergence = off
+	}
+	if len(status.Divergences) < maxReplayDivergences {
+		status.Divergences = append(status.Divergences, playv1alpha1.ReplayDivergence{
+			Offset:   metav1.Duration{Duration: offset},
+			Expected: expected,
+			Actual:   actual,
+		})
+	}
+}
+
+// replayControls are the parts of the airplane that a replay moves, and
+// the rudder it watches.  The controls other than the pedals are nil when
+// the airplane doesn't have them.
+type replayControls struct {
+	pedals    *playv1alpha1.Pedals
+	rudder    *playv1alpha1.Rudder
+	yoke      *playv1alpha1.Yoke
+	trimWheel *playv1alpha1.TrimWheel
+	flapLever *playv1alpha1.FlapLever
+	gearLever *playv1alpha1.GearLever
+}
+
+// applyInputs moves each of the airplane's controls to what a sample
+// recorded, leaving alone the ones that are already there.
+func (r *ReplayReconciler) applyInputs(ctx context.Context, controls *replayControls, sample *flightdata.Sample) error {
+	pedalsSpec := controls.pedals.Spec.DeepCopy()
+	applyPedals(pedalsSpec, sample)
+	if !equality.Semantic.DeepEqual(pedalsSpec, &controls.pedals.Spec) {
+		controls.pedals.Spec = *pedalsSpec
+		if err := r.Update(ctx, controls.pedals); err != nil {
+			return err
+		}
+	}
+
+	if yoke := controls.yoke; yoke != nil && (yoke.Spec.Roll != sample.Roll || yoke.Spec.Pitch != sample.Pitch) {
+		yoke.Spec.Roll = sample.Roll
+		yoke.Spec.Pitch = sample.Pitch
+		if err := r.Update(ctx, yoke); err != nil {
+			return err
+		}
+	}
+
+	if trimWheel := controls.trimWheel; trimWheel != nil && trimWheel.Spec.Setting != sample.Trim {
+		trimWheel.Spec.Setting = sample.Trim
+		if err := r.Update(ctx, trimWheel); err != nil {
+			return err
+		}
+	}
+
+	if flapLever := controls.flapLever; flapLever != nil && flapLever.Spec.Detent != sample.FlapLever {
+		flapLever.Spec.Detent = sample.FlapLever
+		if err := r.Update(ctx, flapLever); err != nil {
+			return err
+		}
+	}
+
+	// A sample that doesn't say where the gear lever is leaves it be.
+	if gearLever := controls.gearLever; gearLever != nil && len(sample.GearLever) > 0 && gearLever.Spec.Position != sample.GearLever {
+		gearLever.Spec.Position = sample.GearLever
+		if err := r.Update(ctx, gearLever); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// applyPedals sets the pedals to what a sample recorded.  The travel is
+// only set when the pressed pedal doesn't account for it.
+func applyPedals(spec *playv1alpha1.PedalsSpec, sample *flightdata.Sample) {
+	spec.Pressed = sample.Pressed
+	if len(spec.Pressed) == 0 {
+		spec.Pressed = "none"
+	}
+	spec.Travel = nil
+	if pedalsTravel(spec) != sample.PedalTravel && sample.PedalTravel != 0 {
+		travel := sample.PedalTravel
+		spec.Travel = &travel
+	}
+}
+
+// timelineError is returned when a replay's timeline can't be read.
+type timelineError struct {
+	message string
+}
+
+func (e *timelineError) Error() string {
+	return e.message
+}
+
+// readTimeline reads the samples of the replay's timeline.
+func (r *ReplayReconciler) readTimeline(ctx context.Context, replay *playv1alpha1.Replay) ([]flightdata.Sample, error) {
+	source := replay.Spec.Timeline
+	configMap := &corev1.ConfigMap{}
+	key := types.NamespacedName{Name: source.ConfigMapRef.Name, Namespace: replay.GetNamespace()}
+	if err := r.APIReader.Get(ctx, key, configMap); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, &timelineError{fmt.Sprintf("ConfigMap %q was not found", key.Name)}
+		}
+		return nil, err
+	}
+	data, ok := configMap.Data[source.Key]
+	if !ok {
+		return nil, &timelineError{fmt.Sprintf("ConfigMap %q has no key %q", key.Name, source.Key)}
+	}
+	samples, err := flightdata.ParseTimeline(source.Key, []byte(data))
+	if err != nil {
+		return nil, &timelineError{fmt.Sprintf("Timeline %q in ConfigMap %q is not valid: %v", source.Key, key.Name, err)}
+	}
+	return samples, nil
+}
+
+// getControls gets the controls of the replay's airplane.  It returns a
+// NotFound error until the airplane has its pedals and rudder.
+func (r *ReplayReconciler) getControls(ctx context.Context, replay *playv1alpha1.Replay) (*replayControls, error) {
+	airplane := &playv1alpha1.Airplane{}
+	key := types.NamespacedName{Name: replay.Spec.AirplaneRef.Name, Namespace: replay.GetNamespace()}
+	if err := r.Get(ctx, key, airplane); err != nil {
+		return nil, err
+	}
+
+	controls := &replayControls{
+		pedals: &playv1alpha1.Pedals{},
+		rudder: &playv1alpha1.Rudder{},
+	}
+	for _, part := range []struct {
+		resource string
+		ref      corev1.ObjectReference
+		obj      client.Object
+	}{
+		{"pedals", airplane.Status.Pedals, controls.pedals},
+		{"rudders", airplane.Status.Rudder, controls.rudder},
+	} {
+		found, err := getPart(ctx, r.Client, airplane.GetNamespace(), part.ref, part.obj)
+		if err != nil {
+			return nil, err
+		}
+		if !found {
+			return nil, apierrors.NewNotFound(playv1alpha1.GroupVersion.WithResource(part.resource).GroupResource(), part.ref.Name)
+		}
+	}
+
+	yoke := &playv1alpha1.Yoke{}
+	if found, err := getPart(ctx, r.Client, airplane.GetNamespace(), airplane.Status.Yoke, yoke); err != nil {
+		return nil, err
+	} else if found {
+		controls.yoke = yoke
+	}
+	trimWheel := &playv1alpha1.TrimWheel{}
+	if found, err := getPart(ctx, r.Client, airplane.GetNamespace(), airplane.Status.TrimWheel, trimWheel); err != nil {
+		return nil, err
+	} else if found {
+		controls.trimWheel = trimWheel
+	}
+	flapLever := &playv1alpha1.FlapLever{}
+	if found, err := getPart(ctx, r.Client, airplane.GetNamespace(), airplane.Status.FlapLever, flapLever); err != nil {
+		return nil, err
+	} else if found {
+		controls.flapLever = flapLever
+	}
+	gearLever := &playv1alpha1.GearLever{}
+	if found, err := getPart(ctx, r.Client, airplane.GetNamespace(), airplane.Status.GearLever, gearLever); err != nil {
+		return nil, err
+	} else if found {
+		controls.gearLever = gearLever
+	}
+	return controls, nil
+}
+
+// Write the replay's new status, if it changed, and pass on the result.
+func (r *ReplayReconciler) updateStatus(ctx context.Context, replay *playv1alpha1.Replay, status *playv1alpha1.ReplayStatus, result ctrl.Result) (ctrl.Result, error) {
+	log := log.FromContext(ctx).WithName("replay")
+
+	if equality.Semantic.DeepEqual(status, &replay.Status) {
+		return result, nil
+	}
+	replay.Status = *status
+	if err := r.Status().Update(ctx, replay); err != nil {
+		if apierrors.IsConflict(err) {
+			log.Info("Conflict while setting status")
+			return ctrl.Result{Requeue: true}, nil
+		}
+		log.Error(err, "Unable to set status")
+		return ctrl.Result{}, err
+	}
+	return result, nil
+}
+
+// replaysForAirplane maps an airplane to the replays that play on it, so a
+// replay starts once its airplane has been assembled.
+func (r *ReplayReconciler) replaysForAirplane(obj client.Object) []reconcile.Request {
+	replays := &playv1alpha1.ReplayList{}
+	if err := r.List(context.Background(), replays, client.InNamespace(obj.GetNamespace())); err != nil {
+		return nil
+	}
+	requests := []reconcile.Request{}
+	for _, replay := range replays.Items {
+		if replay.Spec.AirplaneRef.Name == obj.GetName() && replay.Status.Phase != playv1alpha1.ReplayPlaying && replay.Status.Phase != playv1alpha1.ReplayFinished {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&replay)})
+		}
+	}
+	return requests
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *ReplayReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&playv1alpha1.Replay{}).
+		Watches(&source.Kind{Type: &playv1alpha1.Airplane{}}, handler.EnqueueRequestsFromMapFunc(r.replaysForAirplane)).
+		Watches(&source.Kind{Type: &playv1alpha1.SimClock{}}, handler.EnqueueRequestsFromMapFunc(clockWatcher(mgr.GetClient(), &playv1alpha1.ReplayList{}))).
+		Complete(r)
+}
@@ -0,0 +1,204 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	playv1alpha1 "github.com/roehrich-hpe/airplane-sim/api/v1alpha1"
+)
+
+var _ = Describe("Replay Unit Tests", func() {
+
+	var (
+		key       types.NamespacedName
+		airplane  *playv1alpha1.Airplane
+		timeline  *corev1.ConfigMap
+		replay    *playv1alpha1.Replay
+		recording string
+		sweepKey  string
+	)
+
+	BeforeEach(func() {
+		key = types.NamespacedName{
+			Name:      uuid.New().String()[0:8],
+			Namespace: corev1.NamespaceDefault,
+		}
+
+		airplane = &playv1alpha1.Airplane{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      key.Name,
+				Namespace: key.Namespace,
+			},
+			Spec: playv1alpha1.AirplaneSpec{
+				TailNumber: newTailNumber(),
+			},
+		}
+		Expect(k8sClient.Create(context.TODO(), airplane)).To(Succeed())
+
+		// The default rudder travels 25 degrees either way, at 60
+		// degrees a second.
+		recording = "time,pressed,rudderDeflection\n" +
+			"0s,left,0\n" +
+			"1s,right,-25\n" +
+			"2s,none,25\n" +
+			"3s,none,0\n"
+		sweepKey = "sweep.csv"
+	})
+
+	JustBeforeEach(func() {
+		timeline = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      key.Name + "-timeline",
+				Namespace: key.Namespace,
+			},
+			Data: map[string]string{
+				"sweep.csv": recording,
+			},
+		}
+		Expect(k8sClient.Create(context.TODO(), timeline)).To(Succeed())
+
+		replay = &playv1alpha1.Replay{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      key.Name,
+				Namespace: key.Namespace,
+			},
+			Spec: playv1alpha1.ReplaySpec{
+				AirplaneRef: corev1.LocalObjectReference{Name: key.Name},
+				Timeline: playv1alpha1.TimelineSource{
+					ConfigMapRef: corev1.LocalObjectReference{Name: timeline.GetName()},
+					Key:          sweepKey,
+				},
+			},
+		}
+		Expect(k8sClient.Create(context.TODO(), replay)).To(Succeed())
+	})
+
+	AfterEach(func() {
+		Expect(k8sClient.Delete(context.TODO(), replay)).To(Succeed())
+		Expect(k8sClient.Delete(context.TODO(), timeline)).To(Succeed())
+		Expect(k8sClient.Delete(context.TODO(), airplane)).To(Succeed())
+
+		// Test env limitation: Wait until the cached object is gone.
+		expected := &playv1alpha1.Airplane{}
+		Eventually(func() error {
+			return k8sClient.Get(context.TODO(), key, expected)
+		}).WithTimeout(5 * time.Second).ShouldNot(Succeed())
+	})
+
+	It("Plays the pedals back and follows the recording", func() {
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(context.TODO(), key, replay)).To(Succeed())
+			g.Expect(replay.Status.Phase).To(Equal(playv1alpha1.ReplayFinished))
+		}).WithTimeout(8 * time.Second).Should(Succeed())
+
+		Expect(replay.Status.Samples).To(Equal(int32(4)))
+		Expect(replay.Status.NextSample).To(Equal(int32(4)))
+		Expect(replay.Status.Compared).To(Equal(int32(4)))
+		Expect(replay.Status.DivergenceCount).To(BeZero())
+		Expect(meta.IsStatusConditionTrue(replay.Status.Conditions, playv1alpha1.ConditionReady)).To(BeTrue())
+		Expect(meta.IsStatusConditionFalse(replay.Status.Conditions, playv1alpha1.ConditionDiverged)).To(BeTrue())
+
+		pedals := &playv1alpha1.Pedals{}
+		Expect(k8sClient.Get(context.TODO(), types.NamespacedName{Name: strings.ToLower(airplane.Spec.TailNumber), Namespace: key.Namespace}, pedals)).To(Succeed())
+		Expect(pedals.Spec.Pressed).To(Equal("none"))
+	})
+
+	When("the recording moves the other controls", func() {
+		BeforeEach(func() {
+			recording = "time,roll,pitch,trim,flapLever,gearLever,rudderDeflection\n" +
+				"0s,0,0,0,0,down,0\n" +
+				"1s,-30,20,10,20,up,0\n"
+		})
+
+		It("Plays them back", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(k8sClient.Get(context.TODO(), key, replay)).To(Succeed())
+				g.Expect(replay.Status.Phase).To(Equal(playv1alpha1.ReplayFinished))
+			}).WithTimeout(5 * time.Second).Should(Succeed())
+
+			ckey := types.NamespacedName{Name: strings.ToLower(airplane.Spec.TailNumber), Namespace: key.Namespace}
+			yoke := &playv1alpha1.Yoke{}
+			Expect(k8sClient.Get(context.TODO(), ckey, yoke)).To(Succeed())
+			Expect(yoke.Spec.Roll).To(Equal(int32(-30)))
+			Expect(yoke.Spec.Pitch).To(Equal(int32(20)))
+			trimWheel := &playv1alpha1.TrimWheel{}
+			Expect(k8sClient.Get(context.TODO(), ckey, trimWheel)).To(Succeed())
+			Expect(trimWheel.Spec.Setting).To(Equal(int32(10)))
+			flapLever := &playv1alpha1.FlapLever{}
+			Expect(k8sClient.Get(context.TODO(), ckey, flapLever)).To(Succeed())
+			Expect(flapLever.Spec.Detent).To(Equal(int32(20)))
+			gearLever := &playv1alpha1.GearLever{}
+			Expect(k8sClient.Get(context.TODO(), ckey, gearLever)).To(Succeed())
+			Expect(gearLever.Spec.Position).To(Equal("up"))
+		})
+	})
+
+	When("the recording doesn't match the airplane", func() {
+		BeforeEach(func() {
+			recording = "time,pressed,rudderDeflection\n" +
+				"0s,left,0\n" +
+				"1s,none,10\n"
+		})
+
+		It("Reports the divergence", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(k8sClient.Get(context.TODO(), key, replay)).To(Succeed())
+				g.Expect(replay.Status.Phase).To(Equal(playv1alpha1.ReplayFinished))
+			}).WithTimeout(5 * time.Second).Should(Succeed())
+
+			Expect(replay.Status.DivergenceCount).To(Equal(int32(1)))
+			Expect(replay.Status.MaxDivergence).To(Equal(int32(35)))
+			Expect(replay.Status.Divergences).To(ConsistOf(playv1alpha1.ReplayDivergence{
+				Offset:   metav1.Duration{Duration: time.Second},
+				Expected: 10,
+				Actual:   -25,
+			}))
+			condition := meta.FindStatusCondition(replay.Status.Conditions, playv1alpha1.ConditionDiverged)
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+		})
+	})
+
+	When("the timeline is missing", func() {
+		BeforeEach(func() {
+			sweepKey = "missing.csv"
+		})
+
+		It("Waits for it", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(k8sClient.Get(context.TODO(), key, replay)).To(Succeed())
+				condition := meta.FindStatusCondition(replay.Status.Conditions, playv1alpha1.ConditionReady)
+				g.Expect(condition).NotTo(BeNil())
+				g.Expect(condition.Reason).To(Equal("TimelineNotReady"))
+			}).Should(Succeed())
+			Expect(replay.Status.Phase).To(Equal(playv1alpha1.ReplayPending))
+			Expect(replay.Status.StartTime).To(BeNil())
+		})
+	})
+})
@@ -0,0 +1,168 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	playv1alpha1 "github.com/roehrich-hpe/airplane-sim/api/v1alpha1"
+)
+
+// partRefs returns the references to each of the airplane's parts.
+func partRefs(status *playv1alpha1.AirplaneStatus) []*corev1.ObjectReference {
+	return []*corev1.ObjectReference{
+		&status.Rudder,
+		&status.Pedals,
+		&status.Yoke,
+		&status.Aileron,
+		&status.Elevator,
+		&status.TrimWheel,
+		&status.FlapLever,
+		&status.Flaps,
+		&status.GearLever,
+		&status.LandingGear,
+	}
+}
+
+// Re-register the airplane under the tail number in its spec.  The parts
+// are named after the tail number, so each part that still has the old name
+// is recreated under the new name with its current spec and status, and the
+// old one is deleted.  The old tail number goes into the airplane's history.
+func (r *AirplaneReconciler) reregister(ctx context.Context, airplane *playv1alpha1.Airplane, aircraftType *playv1alpha1.AircraftTypeSpec) (bool, error) {
+	log := r.Log.WithName("registration")
+
+	tailNumber := airplane.Spec.TailNumber
+	name := strings.ToLower(tailNumber)
+	previous := airplane.Status.TailNumber
+	status := airplane.Status.DeepCopy()
+
+	for _, ref := range partRefs(status) {
+		if len(ref.Name) == 0 || ref.Name == name {
+			continue
+		}
+		if len(previous) == 0 {
+			// Parts from before the status kept the tail number.
+			previous = strings.ToUpper(ref.Name)
+		}
+		if err := r.movePart(ctx, airplane, ref, name); err != nil {
+			if _, ok := err.(*partConflict); ok {
+				return false, err
+			}
+			log.Error(err, "Unable to move part", "kind", ref.Kind, "from", ref.Name, "to", name)
+			return false, err
+		}
+	}
+
+	if len(previous) > 0 && previous != tailNumber {
+		status.PreviousRegistrations = append(status.PreviousRegistrations, playv1alpha1.PreviousRegistration{
+			TailNumber: previous,
+			Until:      metav1.Now(),
+		})
+		log.Info("Re-registered airplane", "from", previous, "to", tailNumber)
+		forgetAirplane(previous, airplane.GetNamespace())
+	}
+	status.TailNumber = tailNumber
+
+	if equality.Semantic.DeepEqual(status, &airplane.Status) {
+		return false, nil
+	}
+	airplane.Status = *status
+	if err := r.Status().Update(ctx, airplane); err != nil {
+		log.Error(err, "Unable to record registration in airplane")
+		return false, err
+	}
+
+	return true, nil
+}
+
+// Move a part to a new name, and point the reference at it.  A part that is
+// already gone is left for the verify functions to create again.
+func (r *AirplaneReconciler) movePart(ctx context.Context, airplane *playv1alpha1.Airplane, ref *corev1.ObjectReference, name string) error {
+	log := r.Log.WithName(strings.ToLower(ref.Kind))
+
+	obj, err := r.Scheme.New(playv1alpha1.GroupVersion.WithKind(ref.Kind))
+	if err != nil {
+		return err
+	}
+	old := obj.(client.Object)
+	if err := r.Get(ctx, client.ObjectKey{Name: ref.Name, Namespace: airplane.GetNamespace()}, old); err != nil {
+		if errors.IsNotFound(err) {
+			*ref = corev1.ObjectReference{}
+			return nil
+		}
+		return err
+	}
+
+	part := old.DeepCopyObject().(client.Object)
+	part.SetName(name)
+	part.SetResourceVersion("")
+	part.SetUID("")
+	part.SetGeneration(0)
+	part.SetCreationTimestamp(metav1.Time{})
+	part.SetManagedFields(nil)
+	if err := r.Create(ctx, part); err != nil {
+		if !errors.IsAlreadyExists(err) {
+			return err
+		}
+		// Made on an earlier pass that didn't get to delete the
+		// old part, unless it belongs to someone else.
+		if err := r.Get(ctx, client.ObjectKeyFromObject(part), part); err != nil {
+			return err
+		}
+		if err := checkOwner(airplane, part); err != nil {
+			return err
+		}
+	} else {
+		// Carry over the part's state.
+		if err := copyStatus(old, part); err != nil {
+			return err
+		}
+		if err := r.Status().Update(ctx, part); err != nil {
+			return err
+		}
+		log.Info("Moved part", "from", old.GetName(), "to", name)
+	}
+
+	if err := r.Delete(ctx, old); err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+
+	ref.Name = name
+	return nil
+}
+
+// copyStatus copies the status of one part to another of the same kind.
+func copyStatus(from, to client.Object) error {
+	fromContent, err := runtime.DefaultUnstructuredConverter.ToUnstructured(from)
+	if err != nil {
+		return err
+	}
+	toContent, err := runtime.DefaultUnstructuredConverter.ToUnstructured(to)
+	if err != nil {
+		return err
+	}
+	toContent["status"] = fromContent["status"]
+	return runtime.DefaultUnstructuredConverter.FromUnstructured(toContent, to)
+}
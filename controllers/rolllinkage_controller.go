@@ -0,0 +1,104 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	playv1alpha1 "github.com/roehrich-hpe/airplane-sim/api/v1alpha1"
+)
+
+// RollLinkageReconciler reconciles the roll linkage between a Yoke and
+// its Aileron
+type RollLinkageReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+}
+
+//+kubebuilder:rbac:groups=play.github.com,resources=yokes,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=play.github.com,resources=yokes/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=play.github.com,resources=yokes/finalizers,verbs=update
+
+// Reconcile moves the roll linkage to follow the yoke, and moves the
+// ailerons in opposite directions to follow the linkage.
+//
+// For more details, check Reconcile and its Result here:
+// - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.12.2/pkg/reconcile
+func (r *RollLinkageReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := log.FromContext(ctx).WithName("rolllinkage")
+
+	yoke := &playv1alpha1.Yoke{}
+	if err := r.Get(ctx, req.NamespacedName, yoke); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	travel := clamp(yoke.Spec.Roll, -100, 100)
+	if yoke.Status.RollLinkageTravel != travel {
+		log.Info("Resetting roll linkage")
+		yoke.Status.RollLinkageTravel = travel
+		if err := r.Status().Update(ctx, yoke); err != nil {
+			if apierrors.IsConflict(err) {
+				log.Info("Conflict while setting roll linkage")
+				return ctrl.Result{Requeue: true}, nil
+			}
+			log.Error(err, "Error while setting roll linkage")
+			return ctrl.Result{}, err
+		}
+	}
+
+	// Get the ailerons, move them if necessary.
+	aileron := &playv1alpha1.Aileron{}
+	// Aileron and Yoke have the same name.
+	aileronKey := req.NamespacedName
+	if err := r.Get(ctx, aileronKey, aileron); err != nil {
+		log.Error(err, "Did not find aileron")
+		return ctrl.Result{RequeueAfter: time.Second * 10}, nil
+	}
+
+	// Rolling to the right raises the right aileron and lowers the left
+	// one, and rolling to the left does the opposite.
+	left := scaleTravel(travel, aileron.Spec.MinDeflection, aileron.Spec.MaxDeflection)
+	right := scaleTravel(-travel, aileron.Spec.MinDeflection, aileron.Spec.MaxDeflection)
+	if aileron.Spec.Left != left || aileron.Spec.Right != right {
+		aileron.Spec.Left = left
+		aileron.Spec.Right = right
+		if err := r.Update(ctx, aileron, client.FieldOwner(playv1alpha1.LinkageFieldManager)); err != nil {
+			if apierrors.IsConflict(err) {
+				log.Info("Conflict on aileron")
+				return ctrl.Result{Requeue: true}, nil
+			}
+			log.Error(err, "Error on aileron")
+			return ctrl.Result{}, err
+		}
+		log.Info("aileron has been set")
+	}
+	return ctrl.Result{}, nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *RollLinkageReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&playv1alpha1.Yoke{}).
+		Complete(r)
+}
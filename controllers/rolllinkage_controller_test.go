@@ -0,0 +1,124 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	playv1alpha1 "github.com/roehrich-hpe/airplane-sim/api/v1alpha1"
+)
+
+var _ = Describe("RollLinkage Unit Tests", func() {
+
+	var (
+		key  types.NamespacedName
+		yoke *playv1alpha1.Yoke
+
+		aileron *playv1alpha1.Aileron
+	)
+
+	BeforeEach(func() {
+		// Yoke and aileron have the same value for the name.
+		key = types.NamespacedName{
+			Name:      uuid.New().String()[0:8],
+			Namespace: corev1.NamespaceDefault,
+		}
+
+		yoke = &playv1alpha1.Yoke{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      key.Name,
+				Namespace: key.Namespace,
+			},
+		}
+
+		Expect(k8sClient.Create(context.TODO(), yoke)).To(Succeed())
+
+		aileron = &playv1alpha1.Aileron{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      key.Name,
+				Namespace: key.Namespace,
+			},
+		}
+
+		Expect(k8sClient.Create(context.TODO(), aileron)).To(Succeed())
+	})
+
+	AfterEach(func() {
+		Expect(k8sClient.Delete(context.TODO(), yoke)).To(Succeed())
+		Expect(k8sClient.Delete(context.TODO(), aileron)).To(Succeed())
+
+		// Test env limitation: Wait until the cached object is gone.
+		expected := &playv1alpha1.Yoke{}
+		Eventually(func() error {
+			return k8sClient.Get(context.TODO(), key, expected)
+		}).ShouldNot(Succeed())
+	})
+
+	It("Starts with the ailerons neutral", func() {
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(context.TODO(), key, aileron)).To(Succeed())
+			g.Expect(aileron.Spec.Left).To(BeZero())
+			g.Expect(aileron.Spec.Right).To(BeZero())
+			g.Expect(aileron.Status.Left).To(BeZero())
+			g.Expect(aileron.Status.Right).To(BeZero())
+		}).Should(Succeed())
+	})
+
+	DescribeTable("Moves the ailerons in opposite directions",
+		func(roll int32) {
+			By("turning the yoke")
+			Eventually(func(g Gomega) {
+				g.Expect(k8sClient.Get(context.TODO(), key, yoke)).To(Succeed())
+				yoke.Spec.Roll = roll
+				g.Expect(k8sClient.Update(context.TODO(), yoke)).To(Succeed())
+			}).Should(Succeed())
+
+			By("watching the linkage move")
+			expected := &playv1alpha1.Yoke{}
+			Eventually(func(g Gomega) {
+				g.Expect(k8sClient.Get(context.TODO(), key, expected)).To(Succeed())
+				g.Expect(expected.Status.RollLinkageTravel).To(Equal(roll))
+			}).Should(Succeed())
+
+			By("checking that the ailerons moved")
+			aileronExpected := &playv1alpha1.Aileron{}
+			Eventually(func(g Gomega) {
+				g.Expect(k8sClient.Get(context.TODO(), key, aileronExpected)).To(Succeed())
+				spec := aileronExpected.Spec
+				g.Expect(aileronExpected.Status.Left).To(Equal(scaleTravel(roll, spec.MinDeflection, spec.MaxDeflection)))
+				g.Expect(aileronExpected.Status.Right).To(Equal(scaleTravel(-roll, spec.MinDeflection, spec.MaxDeflection)))
+				if roll > 0 {
+					g.Expect(aileronExpected.Status.Left).To(BeNumerically(">", 0))
+					g.Expect(aileronExpected.Status.Right).To(BeNumerically("<", 0))
+				} else {
+					g.Expect(aileronExpected.Status.Left).To(BeNumerically("<", 0))
+					g.Expect(aileronExpected.Status.Right).To(BeNumerically(">", 0))
+				}
+			}).Should(Succeed())
+		},
+		Entry("when rolling right", int32(50)),
+		Entry("when rolling hard left", int32(-100)),
+	)
+})
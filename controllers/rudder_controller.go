@@ -18,12 +18,19 @@ package controllers
 
 import (
 	"context"
+	"fmt"
+	"time"
 
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	playv1alpha1 "github.com/roehrich-hpe/airplane-sim/api/v1alpha1"
 )
@@ -31,12 +38,14 @@ import (
 // RudderReconciler reconciles a Rudder object
 type RudderReconciler struct {
 	client.Client
-	Scheme *runtime.Scheme
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
 }
 
 //+kubebuilder:rbac:groups=play.github.com,resources=rudders,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=play.github.com,resources=rudders/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=play.github.com,resources=rudders/finalizers,verbs=update
+//+kubebuilder:rbac:groups="",resources=events,verbs=create;patch
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -59,27 +68,98 @@ func (r *RudderReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctr
 		return ctrl.Result{}, client.IgnoreNotFound(err)
 	}
 
-	if rudder.Status.Position != rudder.Spec.Position {
-		log.Info("Resetting position")
-		rudder.Status.Position = rudder.Spec.Position
+	clock, err := readClock(ctx, r.Client, req.Namespace)
+	if err != nil {
+		log.Error(err, "Unable to read simulation clock")
+		return ctrl.Result{}, err
+	}
+
+	target := rudderTarget(&rudder.Spec)
+	deflection, lastMoved, wait := slew(rudder.Status.Deflection, target, rudder.Spec.SlewRate, rudder.Status.LastMoved, clock.now)
+	position := rudderPosition(deflection)
+
+	labels, measured := partLabels(ctx, r.Client, rudder)
+	if measured {
+		rudderCommandedMetric.With(labels).Set(float64(target))
+		rudderDeflectionMetric.With(labels).Set(float64(deflection))
+	}
+
+	status := rudder.Status.DeepCopy()
+	status.Position = position
+	status.Deflection = deflection
+	status.LastMoved = lastMoved
+	status.ObservedGeneration = rudder.Generation
+	if deflection == target {
+		setCondition(&status.Conditions, rudder.Generation, playv1alpha1.ConditionReady, true, "InPosition", "")
+	} else {
+		setCondition(&status.Conditions, rudder.Generation, playv1alpha1.ConditionReady, false, "Moving", fmt.Sprintf("Moving to %d degrees", target))
+	}
+
+	if !equality.Semantic.DeepEqual(status, &rudder.Status) {
+		if deflection != rudder.Status.Deflection {
+			log.Info("Moving rudder", "deflection", deflection, "target", target)
+		} else if position != rudder.Status.Position {
+			log.Info("Resetting position")
+		}
+		arrived := deflection == target && deflection != rudder.Status.Deflection
+		rudder.Status = *status
 		if err := r.Status().Update(ctx, rudder); err != nil {
 			if apierrors.IsConflict(err) {
 				// You may decide to not log these.  They can
 				// be very common.
 				log.Info("Conflict while setting position")
+				countConflict("rudder", labels, measured)
 				return ctrl.Result{Requeue: true}, nil
 			}
 			log.Error(err, "Error while setting position")
 			return ctrl.Result{}, err
 		}
+		if arrived {
+			recordPartEvent(ctx, r.Client, r.Recorder, rudder, corev1.EventTypeNormal, playv1alpha1.EventRudderMoved, "Rudder %s moved to %d degrees", rudder.GetName(), deflection)
+		}
 	}
 
-	return ctrl.Result{}, nil
+	if measured && deflection == target {
+		if lag, ok := linkageTimer.stop(labels, deflection, time.Now()); ok {
+			linkageLagMetric.With(labels).Observe(lag.Seconds())
+		}
+	}
+
+	return ctrl.Result{RequeueAfter: clock.wallTime(wait)}, nil
+}
+
+// rudderTarget returns the deflection, in degrees, that the spec asks for.
+// An explicit deflection wins over the coarse position, and either one is
+// held within the travel limits.
+func rudderTarget(spec *playv1alpha1.RudderSpec) int32 {
+	minDeflection, maxDeflection := spec.Limits()
+	if spec.Deflection != nil {
+		return clamp(*spec.Deflection, minDeflection, maxDeflection)
+	}
+	switch spec.Position {
+	case "left":
+		return minDeflection
+	case "right":
+		return maxDeflection
+	}
+	return 0
+}
+
+// rudderPosition describes a deflection as one of the coarse positions.
+func rudderPosition(deflection int32) string {
+	switch {
+	case deflection < 0:
+		return "left"
+	case deflection > 0:
+		return "right"
+	}
+	return "neutral"
 }
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *RudderReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&playv1alpha1.Rudder{}).
+		Watches(&source.Kind{Type: &playv1alpha1.SimClock{}}, handler.EnqueueRequestsFromMapFunc(clockWatcher(mgr.GetClient(), &playv1alpha1.RudderList{}))).
 		Complete(r)
 }
@@ -18,12 +18,15 @@ package controllers
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 
@@ -74,7 +77,9 @@ var _ = Describe("Rudder Unit Tests", func() {
 	})
 
 	It("Moves to rudder to next position", func() {
-		Expect(k8sClient.Get(context.TODO(), key, rudder)).To(Succeed())
+		Eventually(func() error {
+			return k8sClient.Get(context.TODO(), key, rudder)
+		}).Should(Succeed())
 
 		var wanted string
 		switch rudder.Spec.Position {
@@ -98,4 +103,89 @@ var _ = Describe("Rudder Unit Tests", func() {
 			g.Expect(expected.Status.Position).To(Equal(wanted))
 		}).Should(Succeed())
 	})
+
+	It("Moves rudder to a partial deflection", func() {
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(context.TODO(), key, rudder)).To(Succeed())
+			deflection := int32(-10)
+			rudder.Spec.Deflection = &deflection
+			g.Expect(k8sClient.Update(context.TODO(), rudder)).To(Succeed())
+		}).Should(Succeed())
+
+		expected := &playv1alpha1.Rudder{}
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(context.TODO(), key, expected)).To(Succeed())
+			g.Expect(expected.Status.Deflection).To(Equal(int32(-10)))
+			g.Expect(expected.Status.Position).To(Equal("left"))
+			g.Expect(expected.Status.LastMoved).To(BeNil())
+		}).Should(Succeed())
+	})
+
+	It("Holds the rudder within its travel limits", func() {
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(context.TODO(), key, rudder)).To(Succeed())
+			deflection := int32(90)
+			rudder.Spec.Deflection = &deflection
+			g.Expect(k8sClient.Update(context.TODO(), rudder)).To(Succeed())
+		}).Should(Succeed())
+
+		expected := &playv1alpha1.Rudder{}
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(context.TODO(), key, expected)).To(Succeed())
+			g.Expect(expected.Status.Deflection).To(Equal(*expected.Spec.MaxDeflection))
+		}).Should(Succeed())
+	})
+
+	It("Takes time to reach full deflection", func() {
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(context.TODO(), key, rudder)).To(Succeed())
+			rudder.Spec.SlewRate = 10
+			rudder.Spec.Position = "right"
+			g.Expect(k8sClient.Update(context.TODO(), rudder)).To(Succeed())
+		}).Should(Succeed())
+		started := time.Now()
+
+		By("watching the rudder pass through an intermediate deflection")
+		expected := &playv1alpha1.Rudder{}
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(context.TODO(), key, expected)).To(Succeed())
+			g.Expect(expected.Status.Deflection).To(BeNumerically(">", 0))
+			g.Expect(expected.Status.Deflection).To(BeNumerically("<", *expected.Spec.MaxDeflection))
+			g.Expect(meta.IsStatusConditionFalse(expected.Status.Conditions, playv1alpha1.ConditionReady)).To(BeTrue())
+		}).Should(Succeed())
+
+		By("waiting for the rudder to reach the travel limit")
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(context.TODO(), key, expected)).To(Succeed())
+			g.Expect(expected.Status.Deflection).To(Equal(*expected.Spec.MaxDeflection))
+			g.Expect(meta.IsStatusConditionTrue(expected.Status.Conditions, playv1alpha1.ConditionReady)).To(BeTrue())
+			g.Expect(expected.Status.ObservedGeneration).To(Equal(expected.Generation))
+		}).WithTimeout(5 * time.Second).Should(Succeed())
+		Expect(time.Since(started)).To(BeNumerically(">=", 2*time.Second))
+	})
+
+	It("Rejects a slew rate faster than the actuator can move", func() {
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(context.TODO(), key, rudder)).To(Succeed())
+			rudder.Spec.SlewRate = 2000000000
+			err := k8sClient.Update(context.TODO(), rudder)
+			g.Expect(apierrors.IsInvalid(err)).To(BeTrue())
+		}).Should(Succeed())
+	})
+
+	It("Jumps to the target when the slew rate is too fast to time", func() {
+		lastMoved := metav1.NewMicroTime(time.Now())
+		position, moved, wait := slew(0, 25, 2000000000, &lastMoved, time.Now())
+		Expect(position).To(Equal(int32(25)))
+		Expect(moved).To(BeNil())
+		Expect(wait).To(BeZero())
+	})
+
+	It("Jumps to the target when there is no slew rate", func() {
+		lastMoved := metav1.NewMicroTime(time.Now())
+		position, moved, wait := slew(0, -25, 0, &lastMoved, time.Now())
+		Expect(position).To(Equal(int32(-25)))
+		Expect(moved).To(BeNil())
+		Expect(wait).To(BeZero())
+	})
 })
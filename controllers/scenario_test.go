@@ -0,0 +1,52 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/roehrich-hpe/airplane-sim/pkg/scenario"
+)
+
+var _ = Describe("Scenario Tests", func() {
+
+	paths, err := filepath.Glob(filepath.Join("..", "config", "scenarios", "*.yaml"))
+	if err != nil {
+		panic(err)
+	}
+
+	for _, path := range paths {
+		path := path
+		It("Passes "+filepath.Base(path), func() {
+			s, err := scenario.Load(path)
+			Expect(err).NotTo(HaveOccurred())
+
+			runner := &scenario.Runner{Client: k8sClient, Namespace: corev1.NamespaceDefault}
+			result := runner.Run(context.TODO(), s)
+			for _, step := range result.Steps {
+				Expect(step.Err).NotTo(HaveOccurred(), step.Name)
+				Expect(step.Skipped).To(BeFalse(), step.Name)
+			}
+		})
+	}
+})
@@ -0,0 +1,109 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	playv1alpha1 "github.com/roehrich-hpe/airplane-sim/api/v1alpha1"
+)
+
+//+kubebuilder:rbac:groups=play.github.com,resources=simclocks,verbs=get;list;watch
+
+// simClock is a reading of the simulation clock in a namespace.
+type simClock struct {
+	// now is the current simulation time.
+	now time.Time
+
+	// rate is how many times faster than real time the simulation is
+	// running.  It is zero while the clock is paused.
+	rate int32
+}
+
+// readClock reads the simulation clock for the namespace.  A namespace
+// without a SimClock runs in real time.
+func readClock(ctx context.Context, c client.Reader, namespace string) (simClock, error) {
+	clock := &playv1alpha1.SimClock{}
+	key := types.NamespacedName{Name: playv1alpha1.SimClockName, Namespace: namespace}
+	if err := c.Get(ctx, key, clock); err != nil {
+		if apierrors.IsNotFound(err) {
+			return simClock{now: time.Now(), rate: 1}, nil
+		}
+		return simClock{}, err
+	}
+	return clockAt(&clock.Status, time.Now()), nil
+}
+
+// clockAt works out the simulation time at the given wall-clock time.  A
+// clock that hasn't been started yet runs in real time.
+func clockAt(status *playv1alpha1.SimClockStatus, wall time.Time) simClock {
+	if status.Time == nil || status.Anchor == nil {
+		return simClock{now: wall, rate: 1}
+	}
+	elapsed := wall.Sub(status.Anchor.Time) * time.Duration(status.Rate)
+	return simClock{now: status.Time.Add(elapsed), rate: status.Rate}
+}
+
+// wallTime converts a wait in simulation time into real time.  Nothing
+// moves while the clock is paused, so there is nothing to wait for; the
+// clock's watch wakes everyone when it is resumed or stepped.
+func (c simClock) wallTime(wait time.Duration) time.Duration {
+	if c.rate <= 0 {
+		return 0
+	}
+	return wait / time.Duration(c.rate)
+}
+
+// clockWatcher returns a map function that wakes every object of the list's
+// kind in the namespace of a changed SimClock, so moving parts notice when
+// the clock is paused, resumed, sped up or stepped.
+func clockWatcher(c client.Client, list client.ObjectList) handler.MapFunc {
+	return func(obj client.Object) []reconcile.Request {
+		if obj.GetName() != playv1alpha1.SimClockName {
+			return nil
+		}
+
+		objects := list.DeepCopyObject().(client.ObjectList)
+		if err := c.List(context.Background(), objects, client.InNamespace(obj.GetNamespace())); err != nil {
+			return nil
+		}
+		items, err := meta.ExtractList(objects)
+		if err != nil {
+			return nil
+		}
+
+		requests := make([]reconcile.Request, 0, len(items))
+		for _, item := range items {
+			o, ok := item.(client.Object)
+			if !ok {
+				continue
+			}
+			requests = append(requests, reconcile.Request{
+				NamespacedName: client.ObjectKeyFromObject(o),
+			})
+		}
+		return requests
+	}
+}
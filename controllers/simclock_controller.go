@@ -0,0 +1,104 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	playv1alpha1 "github.com/roehrich-hpe/airplane-sim/api/v1alpha1"
+)
+
+// SimClockReconciler reconciles a SimClock object
+type SimClockReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+}
+
+//+kubebuilder:rbac:groups=play.github.com,resources=simclocks,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=play.github.com,resources=simclocks/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=play.github.com,resources=simclocks/finalizers,verbs=update
+
+// Reconcile re-anchors the simulation clock whenever it is paused, resumed,
+// changes rate or is stepped.  Simulation time carries on from where it was,
+// so nothing jumps.
+//
+// For more details, check Reconcile and its Result here:
+// - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.12.2/pkg/reconcile
+func (r *SimClockReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := log.FromContext(ctx).WithName("simclock")
+
+	clock := &playv1alpha1.SimClock{}
+	if err := r.Get(ctx, req.NamespacedName, clock); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	spec := &clock.Spec
+	status := &clock.Status
+	rate := spec.Rate
+	if rate == 0 {
+		rate = 1
+	}
+	if spec.Paused {
+		rate = 0
+	}
+
+	if status.Time != nil && status.Rate == rate && status.Steps == spec.Steps {
+		return ctrl.Result{}, nil
+	}
+
+	wall := time.Now()
+	now := clockAt(status, wall).now
+	if spec.Paused && spec.Steps > status.Steps && spec.StepSize != nil {
+		now = now.Add(time.Duration(spec.Steps-status.Steps) * spec.StepSize.Duration)
+		log.Info("Stepping clock", "steps", spec.Steps-status.Steps, "time", now)
+	}
+	if rate != status.Rate {
+		log.Info("Clock rate changed", "rate", rate)
+	}
+
+	simTime := metav1.NewMicroTime(now)
+	anchor := metav1.NewMicroTime(wall)
+	status.Time = &simTime
+	status.Anchor = &anchor
+	status.Rate = rate
+	status.Steps = spec.Steps
+	if err := r.Status().Update(ctx, clock); err != nil {
+		if apierrors.IsConflict(err) {
+			log.Info("Conflict while setting clock")
+			return ctrl.Result{Requeue: true}, nil
+		}
+		log.Error(err, "Error while setting clock")
+		return ctrl.Result{}, err
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *SimClockReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&playv1alpha1.SimClock{}).
+		Complete(r)
+}
@@ -0,0 +1,229 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	playv1alpha1 "github.com/roehrich-hpe/airplane-sim/api/v1alpha1"
+)
+
+var _ = Describe("SimClock Unit Tests", func() {
+
+	var (
+		namespace *corev1.Namespace
+		clockKey  types.NamespacedName
+		clock     *playv1alpha1.SimClock
+		rudderKey types.NamespacedName
+		rudder    *playv1alpha1.Rudder
+	)
+
+	BeforeEach(func() {
+		// The clock is shared by everything in its namespace, so keep
+		// it away from the other tests.
+		namespace = &corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "simclock-" + uuid.New().String()[0:8],
+			},
+		}
+		Expect(k8sClient.Create(context.TODO(), namespace)).To(Succeed())
+
+		clockKey = types.NamespacedName{
+			Name:      playv1alpha1.SimClockName,
+			Namespace: namespace.Name,
+		}
+		clock = &playv1alpha1.SimClock{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      clockKey.Name,
+				Namespace: clockKey.Namespace,
+			},
+			Spec: playv1alpha1.SimClockSpec{
+				Paused: true,
+			},
+		}
+
+		rudderKey = types.NamespacedName{
+			Name:      "rudder-" + uuid.New().String()[0:8],
+			Namespace: namespace.Name,
+		}
+		rudder = &playv1alpha1.Rudder{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      rudderKey.Name,
+				Namespace: rudderKey.Namespace,
+			},
+			Spec: playv1alpha1.RudderSpec{
+				Position: "right",
+				SlewRate: 10,
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		Expect(k8sClient.Create(context.TODO(), clock)).To(Succeed())
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(context.TODO(), clockKey, clock)).To(Succeed())
+			g.Expect(clock.Status.Time).ToNot(BeNil())
+		}).Should(Succeed())
+
+		Expect(k8sClient.Create(context.TODO(), rudder)).To(Succeed())
+	})
+
+	AfterEach(func() {
+		Expect(k8sClient.Delete(context.TODO(), rudder)).To(Succeed())
+		Expect(k8sClient.Delete(context.TODO(), clock)).To(Succeed())
+
+		// Test env limitation: Wait until the cached object is gone.
+		expected := &playv1alpha1.SimClock{}
+		Eventually(func() error {
+			return k8sClient.Get(context.TODO(), clockKey, expected)
+		}).ShouldNot(Succeed())
+	})
+
+	setClock := func(mutate func(*playv1alpha1.SimClockSpec)) {
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(context.TODO(), clockKey, clock)).To(Succeed())
+			mutate(&clock.Spec)
+			g.Expect(k8sClient.Update(context.TODO(), clock)).To(Succeed())
+		}).Should(Succeed())
+	}
+
+	It("Freezes moving parts while paused", func() {
+		Expect(clock.Status.Rate).To(BeZero())
+
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(context.TODO(), rudderKey, rudder)).To(Succeed())
+			g.Expect(rudder.Status.LastMoved).ToNot(BeNil())
+		}).Should(Succeed())
+		Consistently(func(g Gomega) {
+			g.Expect(k8sClient.Get(context.TODO(), rudderKey, rudder)).To(Succeed())
+			g.Expect(rudder.Status.Deflection).To(BeZero())
+		}).Should(Succeed())
+
+		By("resuming the clock")
+		setClock(func(spec *playv1alpha1.SimClockSpec) { spec.Paused = false })
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(context.TODO(), rudderKey, rudder)).To(Succeed())
+			g.Expect(rudder.Status.Deflection).To(Equal(*rudder.Spec.MaxDeflection))
+		}).WithTimeout(5 * time.Second).Should(Succeed())
+	})
+
+	It("Advances one step at a time while paused", func() {
+		// Let the rudder notice it has somewhere to go.
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(context.TODO(), rudderKey, rudder)).To(Succeed())
+			g.Expect(rudder.Status.LastMoved).ToNot(BeNil())
+		}).Should(Succeed())
+
+		setClock(func(spec *playv1alpha1.SimClockSpec) { spec.Steps++ })
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(context.TODO(), clockKey, clock)).To(Succeed())
+			g.Expect(clock.Status.Steps).To(Equal(int32(1)))
+		}).Should(Succeed())
+
+		// One second at ten degrees per second.
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(context.TODO(), rudderKey, rudder)).To(Succeed())
+			g.Expect(rudder.Status.Deflection).To(Equal(int32(10)))
+		}).Should(Succeed())
+		Consistently(func(g Gomega) {
+			g.Expect(k8sClient.Get(context.TODO(), rudderKey, rudder)).To(Succeed())
+			g.Expect(rudder.Status.Deflection).To(Equal(int32(10)))
+		}).Should(Succeed())
+	})
+
+	It("Holds off the teardown timeout while paused", func() {
+		tailNumber := newTailNumber()
+		key := types.NamespacedName{
+			Name:      "airplane-" + uuid.New().String()[0:8],
+			Namespace: namespace.Name,
+		}
+		ckey := types.NamespacedName{
+			Name:      strings.ToLower(tailNumber),
+			Namespace: namespace.Name,
+		}
+		airplane := &playv1alpha1.Airplane{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      key.Name,
+				Namespace: key.Namespace,
+			},
+			Spec: playv1alpha1.AirplaneSpec{
+				TailNumber: tailNumber,
+			},
+		}
+		Expect(k8sClient.Create(context.TODO(), airplane)).To(Succeed())
+
+		By("extending the flaps one step")
+		flaps := &playv1alpha1.Flaps{}
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(context.TODO(), ckey, flaps)).To(Succeed())
+			flaps.Spec.Position = 10
+			flaps.Spec.ExtensionRate = 30
+			g.Expect(k8sClient.Update(context.TODO(), flaps)).To(Succeed())
+		}).Should(Succeed())
+		setClock(func(spec *playv1alpha1.SimClockSpec) { spec.Steps++ })
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(context.TODO(), ckey, flaps)).To(Succeed())
+			g.Expect(flaps.Status.Position).To(Equal(int32(10)))
+		}).Should(Succeed())
+
+		By("deleting the airplane")
+		Expect(k8sClient.Delete(context.TODO(), airplane)).To(Succeed())
+		Consistently(func(g Gomega) {
+			g.Expect(k8sClient.Get(context.TODO(), key, airplane)).To(Succeed())
+			g.Expect(meta.IsStatusConditionTrue(airplane.Status.Conditions, playv1alpha1.ConditionStuck)).To(BeFalse())
+		}).WithTimeout(4 * time.Second).Should(Succeed())
+
+		By("resuming the clock")
+		setClock(func(spec *playv1alpha1.SimClockSpec) { spec.Paused = false })
+		Eventually(func() error {
+			return k8sClient.Get(context.TODO(), key, airplane)
+		}).WithTimeout(3 * time.Second).Should(Satisfy(apierrors.IsNotFound))
+		Expect(k8sClient.Delete(context.TODO(), &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: key.Name + "-final", Namespace: key.Namespace},
+		})).To(Succeed())
+	})
+
+	When("the clock runs fast", func() {
+		BeforeEach(func() {
+			clock.Spec.Paused = false
+			clock.Spec.Rate = 4
+		})
+
+		It("Moves parts faster than real time", func() {
+			started := time.Now()
+			Eventually(func(g Gomega) {
+				g.Expect(k8sClient.Get(context.TODO(), rudderKey, rudder)).To(Succeed())
+				g.Expect(rudder.Status.Deflection).To(Equal(*rudder.Spec.MaxDeflection))
+			}).WithTimeout(5 * time.Second).Should(Succeed())
+
+			// Two and a half seconds of travel in simulation time.
+			Expect(time.Since(started)).To(BeNumerically("<", 2*time.Second))
+		})
+	})
+})
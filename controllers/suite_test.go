@@ -18,9 +18,14 @@ package controllers
 
 import (
 	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
 	"path/filepath"
 	"testing"
+	"time"
 
+	"github.com/google/uuid"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -33,6 +38,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 
 	playv1alpha1 "github.com/roehrich-hpe/airplane-sim/api/v1alpha1"
+	playv1beta1 "github.com/roehrich-hpe/airplane-sim/api/v1beta1"
 	//+kubebuilder:scaffold:imports
 )
 
@@ -56,6 +62,14 @@ var _ = BeforeSuite(func() {
 
 	ctx, cancel = context.WithCancel(context.TODO())
 
+	// The scheme must know every version before the test environment
+	// starts, so it can point the CRDs at the conversion webhook.
+	err := playv1alpha1.AddToScheme(scheme.Scheme)
+	Expect(err).NotTo(HaveOccurred())
+
+	err = playv1beta1.AddToScheme(scheme.Scheme)
+	Expect(err).NotTo(HaveOccurred())
+
 	By("bootstrapping test environment")
 	testEnv = &envtest.Environment{
 		CRDDirectoryPaths:     []string{filepath.Join("..", "config", "crd", "bases")},
@@ -63,44 +77,160 @@ var _ = BeforeSuite(func() {
 		//AttachControlPlaneOutput: true,
 	}
 
-	var err error
 	// cfg is defined in this file globally.
 	cfg, err = testEnv.Start()
 	Expect(err).NotTo(HaveOccurred())
 	Expect(cfg).NotTo(BeNil())
 
-	err = playv1alpha1.AddToScheme(scheme.Scheme)
+	webhookInstallOptions := &testEnv.WebhookInstallOptions
+	k8sManager, err := ctrl.NewManager(cfg, ctrl.Options{
+		Scheme:  scheme.Scheme,
+		Host:    webhookInstallOptions.LocalServingHost,
+		Port:    webhookInstallOptions.LocalServingPort,
+		CertDir: webhookInstallOptions.LocalServingCertDir,
+	})
+	Expect(err).NotTo(HaveOccurred())
+	Expect(k8sManager).NotTo(BeNil())
+
+	// The v1beta1 versions are stored, so the v1alpha1 versions the
+	// reconcilers use must go through the conversion webhook.
+	err = (&playv1beta1.Rudder{}).SetupWebhookWithManager(k8sManager)
 	Expect(err).NotTo(HaveOccurred())
 
-	k8sManager, err := ctrl.NewManager(cfg, ctrl.Options{Scheme: scheme.Scheme})
+	err = (&playv1beta1.Pedals{}).SetupWebhookWithManager(k8sManager)
+	Expect(err).NotTo(HaveOccurred())
+
+	err = (&playv1beta1.Airplane{}).SetupWebhookWithManager(k8sManager)
 	Expect(err).NotTo(HaveOccurred())
-	Expect(k8sManager).NotTo(BeNil())
 
 	k8sClient = k8sManager.GetClient()
 
 	err = (&RudderReconciler{
+		Client:   k8sClient,
+		Scheme:   scheme.Scheme,
+		Recorder: k8sManager.GetEventRecorderFor("rudder-controller"),
+	}).SetupWithManager(k8sManager)
+	Expect(err).ToNot(HaveOccurred())
+
+	err = (&PedalLinkageReconciler{
+		Client:   k8sClient,
+		Scheme:   scheme.Scheme,
+		Recorder: k8sManager.GetEventRecorderFor("pedallinkage-controller"),
+	}).SetupWithManager(k8sManager)
+	Expect(err).ToNot(HaveOccurred())
+
+	err = (&AirplaneReconciler{
+		Client:          k8sClient,
+		Scheme:          scheme.Scheme,
+		Recorder:        k8sManager.GetEventRecorderFor("airplane-controller"),
+		APIReader:       k8sManager.GetAPIReader(),
+		TeardownTimeout: 3 * time.Second,
+	}).SetupWithManager(k8sManager)
+	Expect(err).ToNot(HaveOccurred())
+
+	err = (&RollLinkageReconciler{
 		Client: k8sClient,
 		Scheme: scheme.Scheme,
 	}).SetupWithManager(k8sManager)
 	Expect(err).ToNot(HaveOccurred())
 
-	err = (&PedalLinkageReconciler{
+	err = (&AileronReconciler{
 		Client: k8sClient,
 		Scheme: scheme.Scheme,
 	}).SetupWithManager(k8sManager)
 	Expect(err).ToNot(HaveOccurred())
 
-	err = (&AirplaneReconciler{
+	err = (&PitchLinkageReconciler{
+		Client: k8sClient,
+		Scheme: scheme.Scheme,
+	}).SetupWithManager(k8sManager)
+	Expect(err).ToNot(HaveOccurred())
+
+	err = (&TrimLinkageReconciler{
 		Client: k8sClient,
 		Scheme: scheme.Scheme,
 	}).SetupWithManager(k8sManager)
 	Expect(err).ToNot(HaveOccurred())
 
+	err = (&ElevatorReconciler{
+		Client: k8sClient,
+		Scheme: scheme.Scheme,
+	}).SetupWithManager(k8sManager)
+	Expect(err).ToNot(HaveOccurred())
+
+	err = (&FlapLinkageReconciler{
+		Client: k8sClient,
+		Scheme: scheme.Scheme,
+	}).SetupWithManager(k8sManager)
+	Expect(err).ToNot(HaveOccurred())
+
+	err = (&FlapsReconciler{
+		Client: k8sClient,
+		Scheme: scheme.Scheme,
+	}).SetupWithManager(k8sManager)
+	Expect(err).ToNot(HaveOccurred())
+
+	err = (&GearLinkageReconciler{
+		Client: k8sClient,
+		Scheme: scheme.Scheme,
+	}).SetupWithManager(k8sManager)
+	Expect(err).ToNot(HaveOccurred())
+
+	err = (&LandingGearReconciler{
+		Client: k8sClient,
+		Scheme: scheme.Scheme,
+	}).SetupWithManager(k8sManager)
+	Expect(err).ToNot(HaveOccurred())
+
+	err = (&AirplanePhysicsReconciler{
+		Client: k8sClient,
+		Scheme: scheme.Scheme,
+	}).SetupWithManager(k8sManager)
+	Expect(err).ToNot(HaveOccurred())
+
+	err = (&SimClockReconciler{
+		Client: k8sClient,
+		Scheme: scheme.Scheme,
+	}).SetupWithManager(k8sManager)
+	Expect(err).ToNot(HaveOccurred())
+
+	err = (&LinkageReconciler{
+		Client: k8sClient,
+		Scheme: scheme.Scheme,
+	}).SetupWithManager(k8sManager)
+	Expect(err).ToNot(HaveOccurred())
+
+	err = (&FlightDataRecorderReconciler{
+		Client:    k8sClient,
+		Scheme:    scheme.Scheme,
+		APIReader: k8sManager.GetAPIReader(),
+	}).SetupWithManager(k8sManager)
+	Expect(err).ToNot(HaveOccurred())
+
+	err = (&ReplayReconciler{
+		Client:    k8sClient,
+		Scheme:    scheme.Scheme,
+		APIReader: k8sManager.GetAPIReader(),
+	}).SetupWithManager(k8sManager)
+	Expect(err).ToNot(HaveOccurred())
+
 	go func() {
 		defer GinkgoRecover()
 		err = k8sManager.Start(ctx)
 		Expect(err).ToNot(HaveOccurred(), "failed to run manager")
 	}()
+
+	// wait for the webhook server to get ready
+	dialer := &net.Dialer{Timeout: time.Second}
+	addrPort := fmt.Sprintf("%s:%d", webhookInstallOptions.LocalServingHost, webhookInstallOptions.LocalServingPort)
+	Eventually(func() error {
+		conn, err := tls.DialWithDialer(dialer, "tcp", addrPort, &tls.Config{InsecureSkipVerify: true})
+		if err != nil {
+			return err
+		}
+		conn.Close()
+		return nil
+	}).Should(Succeed())
 })
 
 var _ = AfterSuite(func() {
@@ -109,3 +239,10 @@ var _ = AfterSuite(func() {
 	err := testEnv.Stop()
 	Expect(err).NotTo(HaveOccurred())
 })
+
+// newTailNumber makes up an N-number, so each test gets its own parts.
+func newTailNumber() string {
+	const letters = "ABCDEFGHJKLMNPQRSTUVWXYZ"
+	n := uuid.New().ID()
+	return fmt.Sprintf("N%d%c%c", 100+n%900, letters[n/900%24], letters[n/900/24%24])
+}
@@ -0,0 +1,380 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	playv1alpha1 "github.com/roehrich-hpe/airplane-sim/api/v1alpha1"
+)
+
+// teardownFinalizer keeps an airplane around until it has been parked, its
+// parts deleted, and its registration released.
+const teardownFinalizer = "play.github.com/teardown"
+
+// registrationFinalizer is the name teardownFinalizer had before teardown
+// did more than release the registration.  Airplanes created before the
+// rename still carry it, and it is swapped for teardownFinalizer.
+const registrationFinalizer = "play.github.com/registration"
+
+// defaultTeardownTimeout is how long a deleted airplane waits for its
+// surfaces to stow when the reconciler doesn't say otherwise.
+const defaultTeardownTimeout = time.Minute
+
+// parkingSinceAnnotation holds the simulation time at which a deleted
+// airplane began to wait for its surfaces to stow.
+const parkingSinceAnnotation = "play.github.com/parking-since"
+
+// snapshotAirplaneLabel names the airplane that a final snapshot was taken
+// of.
+const snapshotAirplaneLabel = "play.github.com/airplane"
+
+// snapshotAirplaneUIDAnnotation holds the UID of the airplane that a final
+// snapshot was taken of, to tell it apart from an earlier airplane of the
+// same name.
+const snapshotAirplaneUIDAnnotation = "play.github.com/airplane-uid"
+
+// Tear down an airplane that is being deleted.  The cockpit controls are
+// parked and the airplane waits for its surfaces to stow, so no part is
+// removed in mid-travel.  Then a final snapshot is recorded, the parts are
+// deleted, the registration is released, and the airplane is let go.
+func (r *AirplaneReconciler) finalize(ctx context.Context, airplane *playv1alpha1.Airplane) (ctrl.Result, error) {
+	log := r.Log.WithName("teardown")
+
+	if !controllerutil.ContainsFinalizer(airplane, teardownFinalizer) && !controllerutil.ContainsFinalizer(airplane, registrationFinalizer) {
+		return ctrl.Result{}, nil
+	}
+
+	moving, err := r.park(ctx, airplane)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if len(moving) > 0 {
+		return r.waitForParking(ctx, airplane, moving)
+	}
+
+	if err := r.recordSnapshot(ctx, airplane); err != nil {
+		return ctrl.Result{}, err
+	}
+	if err := r.deleteParts(ctx, airplane); err != nil {
+		return ctrl.Result{}, err
+	}
+	for _, tailNumber := range []string{airplane.Spec.TailNumber, airplane.Status.TailNumber} {
+		if len(tailNumber) == 0 {
+			continue
+		}
+		if err := r.releaseRegistration(ctx, airplane, tailNumber); err != nil {
+			return ctrl.Result{}, err
+		}
+		forgetAirplane(tailNumber, airplane.GetNamespace())
+	}
+
+	controllerutil.RemoveFinalizer(airplane, teardownFinalizer)
+	controllerutil.RemoveFinalizer(airplane, registrationFinalizer)
+	if err := r.Update(ctx, airplane); err != nil {
+		return ctrl.Result{}, err
+	}
+	log.Info("Tore down airplane")
+
+	return ctrl.Result{}, nil
+}
+
+// Park the cockpit controls, and command the surfaces that can be commanded
+// directly to stow.  The rudder follows the pedals.  Returns the surfaces
+// that haven't stowed yet.
+func (r *AirplaneReconciler) park(ctx context.Context, airplane *playv1alpha1.Airplane) ([]string, error) {
+	log := r.Log.WithName("teardown")
+
+	pedals := &playv1alpha1.Pedals{}
+	yoke := &playv1alpha1.Yoke{}
+	flapLever := &playv1alpha1.FlapLever{}
+	trimWheel := &playv1alpha1.TrimWheel{}
+	rudder := &playv1alpha1.Rudder{}
+	aileron := &playv1alpha1.Aileron{}
+	elevator := &playv1alpha1.Elevator{}
+	flaps := &playv1alpha1.Flaps{}
+
+	controls := []struct {
+		ref  corev1.ObjectReference
+		part client.Object
+		park func() bool
+	}{
+		{airplane.Status.Pedals, pedals, func() bool {
+			parked := pedals.Spec.Pressed == "none" && pedals.Spec.Travel == nil
+			pedals.Spec.Pressed = "none"
+			pedals.Spec.Travel = nil
+			return !parked
+		}},
+		{airplane.Status.Yoke, yoke, func() bool {
+			parked := yoke.Spec == playv1alpha1.YokeSpec{}
+			yoke.Spec = playv1alpha1.YokeSpec{}
+			return !parked
+		}},
+		{airplane.Status.FlapLever, flapLever, func() bool {
+			parked := flapLever.Spec.Detent == 0
+			flapLever.Spec.Detent = 0
+			return !parked
+		}},
+		{airplane.Status.TrimWheel, trimWheel, func() bool {
+			parked := trimWheel.Spec.Setting == 0
+			trimWheel.Spec.Setting = 0
+			return !parked
+		}},
+		{airplane.Status.Aileron, aileron, func() bool {
+			parked := aileron.Spec.Left == 0 && aileron.Spec.Right == 0
+			aileron.Spec.Left = 0
+			aileron.Spec.Right = 0
+			return !parked
+		}},
+		{airplane.Status.Elevator, elevator, func() bool {
+			parked := elevator.Spec.Deflection == 0 && elevator.Spec.Trim == 0
+			elevator.Spec.Deflection = 0
+			elevator.Spec.Trim = 0
+			return !parked
+		}},
+		{airplane.Status.Flaps, flaps, func() bool {
+			parked := flaps.Spec.Position == 0
+			flaps.Spec.Position = 0
+			return !parked
+		}},
+	}
+	for _, control := range controls {
+		found, err := r.getPart(ctx, airplane, control.ref, control.part)
+		if err != nil {
+			return nil, err
+		}
+		if !found || !control.park() {
+			continue
+		}
+		if err := r.Update(ctx, control.part); err != nil {
+			return nil, err
+		}
+		log.Info("Parked "+strings.ToLower(control.ref.Kind), "name", control.ref.Name)
+	}
+
+	surfaces := []struct {
+		ref    corev1.ObjectReference
+		part   client.Object
+		stowed func() bool
+	}{
+		{airplane.Status.Rudder, rudder, func() bool { return rudder.Status.Deflection == 0 }},
+		{airplane.Status.Aileron, aileron, func() bool { return aileron.Status.Left == 0 && aileron.Status.Right == 0 }},
+		{airplane.Status.Elevator, elevator, func() bool { return elevator.Status.Deflection == 0 }},
+		{airplane.Status.Flaps, flaps, func() bool { return flaps.Status.Position == 0 }},
+	}
+	moving := []string{}
+	for _, surface := range surfaces {
+		found, err := r.getPart(ctx, airplane, surface.ref, surface.part)
+		if err != nil {
+			return nil, err
+		}
+		if found && !surface.stowed() {
+			moving = append(moving, strings.ToLower(surface.ref.Kind))
+		}
+	}
+	return moving, nil
+}
+
+// Wait for the surfaces to stow.  The airplane owns them, so it hears when
+// they move.  Once the wait has gone on too long the airplane reports that
+// it is stuck, and stays that way until the surfaces stow or someone removes
+// the finalizer.  The wait is measured in simulation time, since nothing
+// moves while the clock is paused.
+func (r *AirplaneReconciler) waitForParking(ctx context.Context, airplane *playv1alpha1.Airplane, moving []string) (ctrl.Result, error) {
+	timeout := r.TeardownTimeout
+	if timeout == 0 {
+		timeout = defaultTeardownTimeout
+	}
+
+	clock, err := readClock(ctx, r, airplane.GetNamespace())
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	since, err := time.Parse(time.RFC3339Nano, airplane.GetAnnotations()[parkingSinceAnnotation])
+	if err != nil {
+		since = clock.now
+		metav1.SetMetaDataAnnotation(&airplane.ObjectMeta, parkingSinceAnnotation, since.Format(time.RFC3339Nano))
+		if err := r.Update(ctx, airplane); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+	remaining := since.Add(timeout).Sub(clock.now)
+
+	generation := airplane.Generation
+	message := fmt.Sprintf("Waiting for the %s to stow", strings.Join(moving, ", "))
+	status := airplane.Status.DeepCopy()
+	setCondition(&status.Conditions, generation, playv1alpha1.ConditionReady, false, "Deleting", "")
+	result := ctrl.Result{RequeueAfter: clock.wallTime(remaining)}
+	if remaining > 0 {
+		setCondition(&status.Conditions, generation, playv1alpha1.ConditionStuck, false, "Parking", message)
+	} else {
+		if !meta.IsStatusConditionTrue(airplane.Status.Conditions, playv1alpha1.ConditionStuck) {
+			r.Log.Info("Teardown is stuck", "moving", moving)
+			r.Recorder.Event(airplane, corev1.EventTypeWarning, "Stuck", message)
+		}
+		setCondition(&status.Conditions, generation, playv1alpha1.ConditionStuck, true, "ParkingTimedOut", message)
+		result = ctrl.Result{}
+	}
+
+	if err := r.updateStatus(ctx, airplane, status); err != nil {
+		return ctrl.Result{}, err
+	}
+	return result, nil
+}
+
+// Record the airplane's final state, and the state of each of its parts, in
+// a ConfigMap that outlives the airplane.
+func (r *AirplaneReconciler) recordSnapshot(ctx context.Context, airplane *playv1alpha1.Airplane) error {
+	log := r.Log.WithName("teardown")
+
+	data := map[string]string{}
+	final, err := json.Marshal(struct {
+		Spec   playv1alpha1.AirplaneSpec   `json:"spec"`
+		Status playv1alpha1.AirplaneStatus `json:"status"`
+	}{airplane.Spec, airplane.Status})
+	if err != nil {
+		return err
+	}
+	data["airplane.json"] = string(final)
+
+	for _, ref := range partRefs(&airplane.Status) {
+		if len(ref.Name) == 0 {
+			continue
+		}
+		part, err := r.Scheme.New(playv1alpha1.GroupVersion.WithKind(ref.Kind))
+		if err != nil {
+			return err
+		}
+		found, err := r.getPart(ctx, airplane, *ref, part.(client.Object))
+		if err != nil {
+			return err
+		}
+		if !found {
+			continue
+		}
+		content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(part)
+		if err != nil {
+			return err
+		}
+		status, err := json.Marshal(content["status"])
+		if err != nil {
+			return err
+		}
+		data[strings.ToLower(ref.Kind)+".json"] = string(status)
+	}
+
+	snapshot := &corev1.ConfigMap{}
+	key := client.ObjectKey{Name: airplane.GetName() + "-final", Namespace: airplane.GetNamespace()}
+	if err := r.APIReader.Get(ctx, key, snapshot); err != nil {
+		if !errors.IsNotFound(err) {
+			return err
+		}
+		snapshot = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      key.Name,
+				Namespace: key.Namespace,
+				Labels: map[string]string{
+					snapshotAirplaneLabel: airplane.GetName(),
+				},
+				Annotations: map[string]string{
+					snapshotAirplaneUIDAnnotation: string(airplane.GetUID()),
+				},
+			},
+			Data: data,
+		}
+		if err := r.Create(ctx, snapshot); err != nil {
+			return err
+		}
+	} else {
+		if snapshot.GetLabels()[snapshotAirplaneLabel] != airplane.GetName() {
+			log.Info("Not overwriting someone else's ConfigMap with the final snapshot", "configMap", key.Name)
+			return nil
+		}
+		if snapshot.GetAnnotations()[snapshotAirplaneUIDAnnotation] == string(airplane.GetUID()) {
+			// Recorded on an earlier pass, which may have gone on
+			// to delete the parts.  Keep the first one.
+			return nil
+		}
+		// Left by an earlier airplane of the same name.
+		snapshot.Data = data
+		metav1.SetMetaDataAnnotation(&snapshot.ObjectMeta, snapshotAirplaneUIDAnnotation, string(airplane.GetUID()))
+		if err := r.Update(ctx, snapshot); err != nil {
+			return err
+		}
+	}
+	log.Info("Recorded final snapshot", "configMap", key.Name)
+
+	return nil
+}
+
+// Delete each of the airplane's parts, rather than leave them for the
+// garbage collector.
+func (r *AirplaneReconciler) deleteParts(ctx context.Context, airplane *playv1alpha1.Airplane) error {
+	log := r.Log.WithName("teardown")
+
+	for _, ref := range partRefs(&airplane.Status) {
+		if len(ref.Name) == 0 {
+			continue
+		}
+		obj, err := r.Scheme.New(playv1alpha1.GroupVersion.WithKind(ref.Kind))
+		if err != nil {
+			return err
+		}
+		part := obj.(client.Object)
+		found, err := r.getPart(ctx, airplane, *ref, part)
+		if err != nil {
+			return err
+		}
+		if !found || checkOwner(airplane, part) != nil {
+			continue
+		}
+		uid := part.GetUID()
+		if err := r.Delete(ctx, part, client.Preconditions{UID: &uid}); err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+		log.Info("Deleted "+strings.ToLower(ref.Kind), "name", ref.Name)
+	}
+	return nil
+}
+
+// getPart reads the part that a reference in the airplane's status points
+// at.  It reports whether the part was found.
+func (r *AirplaneReconciler) getPart(ctx context.Context, airplane *playv1alpha1.Airplane, ref corev1.ObjectReference, part client.Object) (bool, error) {
+	if len(ref.Name) == 0 {
+		return false, nil
+	}
+	if err := r.Get(ctx, client.ObjectKey{Name: ref.Name, Namespace: airplane.GetNamespace()}, part); err != nil {
+		if errors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
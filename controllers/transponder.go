@@ -0,0 +1,81 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+
+	playv1alpha1 "github.com/roehrich-hpe/airplane-sim/api/v1alpha1"
+	"github.com/roehrich-hpe/airplane-sim/pkg/icao"
+	"github.com/roehrich-hpe/airplane-sim/pkg/tailnumber"
+)
+
+// Publish the Mode S address that goes with the airplane's N-number, in its
+// status and as a label.  Airplanes on other registers have no address.
+func (r *AirplaneReconciler) verifyAddress(ctx context.Context, airplane *playv1alpha1.Airplane, aircraftType *playv1alpha1.AircraftTypeSpec) (bool, error) {
+	log := r.Log.WithName("transponder")
+
+	address := modeSAddress(airplane)
+
+	if airplane.GetLabels()[playv1alpha1.ICAOAddressLabel] != address {
+		labels := airplane.GetLabels()
+		if labels == nil {
+			labels = map[string]string{}
+		}
+		if len(address) == 0 {
+			delete(labels, playv1alpha1.ICAOAddressLabel)
+		} else {
+			labels[playv1alpha1.ICAOAddressLabel] = address
+		}
+		airplane.SetLabels(labels)
+		if err := r.Update(ctx, airplane); err != nil {
+			log.Error(err, "Unable to label airplane with its address")
+			return false, err
+		}
+		log.Info("Labeled airplane", "address", address)
+		return true, nil
+	}
+
+	if airplane.Status.ICAOAddress == address {
+		return false, nil
+	}
+	airplane.Status.ICAOAddress = address
+	if err := r.Status().Update(ctx, airplane); err != nil {
+		log.Error(err, "Unable to set address in airplane")
+		return false, err
+	}
+	log.Info("Updated address", "address", address)
+
+	return true, nil
+}
+
+// modeSAddress works out the airplane's Mode S address, as hexadecimal
+// digits, or returns nothing if the airplane isn't on the United States
+// register.
+func modeSAddress(airplane *playv1alpha1.Airplane) string {
+	country := airplane.Spec.Country
+	if len(country) > 0 && country != tailnumber.DefaultCountry {
+		return ""
+	}
+	address, err := icao.Address(airplane.Spec.TailNumber)
+	if err != nil {
+		// A tail number from before the FAA's rules were
+		// enforced.
+		return ""
+	}
+	return icao.Hex(address)
+}
@@ -0,0 +1,100 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	playv1alpha1 "github.com/roehrich-hpe/airplane-sim/api/v1alpha1"
+)
+
+// TrimLinkageReconciler reconciles the trim linkage between a TrimWheel and
+// the trim tab on its Elevator
+type TrimLinkageReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+}
+
+//+kubebuilder:rbac:groups=play.github.com,resources=trimwheels,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=play.github.com,resources=trimwheels/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=play.github.com,resources=trimwheels/finalizers,verbs=update
+
+// Reconcile moves the trim linkage to follow the trim wheel, and sets the
+// elevator's trim bias to follow the linkage.
+//
+// For more details, check Reconcile and its Result here:
+// - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.12.2/pkg/reconcile
+func (r *TrimLinkageReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := log.FromContext(ctx).WithName("trimlinkage")
+
+	trimWheel := &playv1alpha1.TrimWheel{}
+	if err := r.Get(ctx, req.NamespacedName, trimWheel); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	travel := clamp(trimWheel.Spec.Setting, -100, 100)
+	if trimWheel.Status.TrimTravel != travel {
+		log.Info("Resetting trim linkage")
+		trimWheel.Status.TrimTravel = travel
+		if err := r.Status().Update(ctx, trimWheel); err != nil {
+			if apierrors.IsConflict(err) {
+				log.Info("Conflict while setting trim linkage")
+				return ctrl.Result{Requeue: true}, nil
+			}
+			log.Error(err, "Error while setting trim linkage")
+			return ctrl.Result{}, err
+		}
+	}
+
+	// Get the elevator, trim it if necessary.
+	elevator := &playv1alpha1.Elevator{}
+	// Elevator and TrimWheel have the same name.
+	elevatorKey := req.NamespacedName
+	if err := r.Get(ctx, elevatorKey, elevator); err != nil {
+		log.Error(err, "Did not find elevator")
+		return ctrl.Result{RequeueAfter: time.Second * 10}, nil
+	}
+
+	trim := scaleTravel(travel, -elevator.Spec.MaxTrim, elevator.Spec.MaxTrim)
+	if elevator.Spec.Trim != trim {
+		elevator.Spec.Trim = trim
+		if err := r.Update(ctx, elevator, client.FieldOwner(playv1alpha1.LinkageFieldManager)); err != nil {
+			if apierrors.IsConflict(err) {
+				log.Info("Conflict on elevator")
+				return ctrl.Result{Requeue: true}, nil
+			}
+			log.Error(err, "Error on elevator")
+			return ctrl.Result{}, err
+		}
+		log.Info("elevator trim has been set")
+	}
+	return ctrl.Result{}, nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *TrimLinkageReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&playv1alpha1.TrimWheel{}).
+		Complete(r)
+}
@@ -0,0 +1,107 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	playv1alpha1 "github.com/roehrich-hpe/airplane-sim/api/v1alpha1"
+)
+
+var _ = Describe("TrimLinkage Unit Tests", func() {
+
+	var (
+		key       types.NamespacedName
+		trimWheel *playv1alpha1.TrimWheel
+
+		elevator *playv1alpha1.Elevator
+	)
+
+	BeforeEach(func() {
+		// TrimWheel and elevator have the same value for the name.
+		key = types.NamespacedName{
+			Name:      uuid.New().String()[0:8],
+			Namespace: corev1.NamespaceDefault,
+		}
+
+		trimWheel = &playv1alpha1.TrimWheel{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      key.Name,
+				Namespace: key.Namespace,
+			},
+		}
+
+		Expect(k8sClient.Create(context.TODO(), trimWheel)).To(Succeed())
+
+		elevator = &playv1alpha1.Elevator{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      key.Name,
+				Namespace: key.Namespace,
+			},
+			Spec: playv1alpha1.ElevatorSpec{
+				Deflection: 5,
+			},
+		}
+
+		Expect(k8sClient.Create(context.TODO(), elevator)).To(Succeed())
+	})
+
+	AfterEach(func() {
+		Expect(k8sClient.Delete(context.TODO(), trimWheel)).To(Succeed())
+		Expect(k8sClient.Delete(context.TODO(), elevator)).To(Succeed())
+
+		// Test env limitation: Wait until the cached object is gone.
+		expected := &playv1alpha1.TrimWheel{}
+		Eventually(func() error {
+			return k8sClient.Get(context.TODO(), key, expected)
+		}).ShouldNot(Succeed())
+	})
+
+	It("Biases the elevator's neutral position", func() {
+		By("trimming nose down")
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(context.TODO(), key, trimWheel)).To(Succeed())
+			trimWheel.Spec.Setting = -50
+			g.Expect(k8sClient.Update(context.TODO(), trimWheel)).To(Succeed())
+		}).Should(Succeed())
+
+		By("watching the linkage move")
+		expected := &playv1alpha1.TrimWheel{}
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(context.TODO(), key, expected)).To(Succeed())
+			g.Expect(expected.Status.TrimTravel).To(Equal(int32(-50)))
+		}).Should(Succeed())
+
+		By("checking both commanded and effective deflection")
+		elevatorExpected := &playv1alpha1.Elevator{}
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(context.TODO(), key, elevatorExpected)).To(Succeed())
+			trim := -elevatorExpected.Spec.MaxTrim / 2
+			g.Expect(elevatorExpected.Status.Commanded).To(Equal(int32(5)))
+			g.Expect(elevatorExpected.Status.Trim).To(Equal(trim))
+			g.Expect(elevatorExpected.Status.Deflection).To(Equal(5 + trim))
+		}).Should(Succeed())
+	})
+})
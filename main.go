@@ -18,6 +18,7 @@ package main
 
 import (
 	"flag"
+	"net"
 	"os"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
@@ -32,6 +33,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 
 	playv1alpha1 "github.com/roehrich-hpe/airplane-sim/api/v1alpha1"
+	playv1beta1 "github.com/roehrich-hpe/airplane-sim/api/v1beta1"
 	"github.com/roehrich-hpe/airplane-sim/controllers"
 	//+kubebuilder:scaffold:imports
 )
@@ -45,6 +47,7 @@ func init() {
 	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
 
 	utilruntime.Must(playv1alpha1.AddToScheme(scheme))
+	utilruntime.Must(playv1beta1.AddToScheme(scheme))
 	//+kubebuilder:scaffold:scheme
 }
 
@@ -90,24 +93,169 @@ func main() {
 	}
 
 	if err = (&controllers.RudderReconciler{
-		Client: mgr.GetClient(),
-		Scheme: mgr.GetScheme(),
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Recorder: mgr.GetEventRecorderFor("rudder-controller"),
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "Rudder")
 		os.Exit(1)
 	}
 	if err = (&controllers.PedalLinkageReconciler{
-		Client: mgr.GetClient(),
-		Scheme: mgr.GetScheme(),
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Recorder: mgr.GetEventRecorderFor("pedallinkage-controller"),
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "PedalLinkage")
 		os.Exit(1)
 	}
 	if err = (&controllers.AirplaneReconciler{
+		Client:    mgr.GetClient(),
+		Scheme:    mgr.GetScheme(),
+		Recorder:  mgr.GetEventRecorderFor("airplane-controller"),
+		APIReader: mgr.GetAPIReader(),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "Airplane")
+		os.Exit(1)
+	}
+	if err = (&controllers.RollLinkageReconciler{
 		Client: mgr.GetClient(),
 		Scheme: mgr.GetScheme(),
 	}).SetupWithManager(mgr); err != nil {
-		setupLog.Error(err, "unable to create controller", "controller", "Airplane")
+		setupLog.Error(err, "unable to create controller", "controller", "RollLinkage")
+		os.Exit(1)
+	}
+	if err = (&controllers.AileronReconciler{
+		Client: mgr.GetClient(),
+		Scheme: mgr.GetScheme(),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "Aileron")
+		os.Exit(1)
+	}
+	if err = (&controllers.PitchLinkageReconciler{
+		Client: mgr.GetClient(),
+		Scheme: mgr.GetScheme(),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "PitchLinkage")
+		os.Exit(1)
+	}
+	if err = (&controllers.TrimLinkageReconciler{
+		Client: mgr.GetClient(),
+		Scheme: mgr.GetScheme(),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "TrimLinkage")
+		os.Exit(1)
+	}
+	if err = (&controllers.ElevatorReconciler{
+		Client: mgr.GetClient(),
+		Scheme: mgr.GetScheme(),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "Elevator")
+		os.Exit(1)
+	}
+	if err = (&controllers.FlapLinkageReconciler{
+		Client: mgr.GetClient(),
+		Scheme: mgr.GetScheme(),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "FlapLinkage")
+		os.Exit(1)
+	}
+	if err = (&controllers.FlapsReconciler{
+		Client: mgr.GetClient(),
+		Scheme: mgr.GetScheme(),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "Flaps")
+		os.Exit(1)
+	}
+	if err = (&controllers.GearLinkageReconciler{
+		Client: mgr.GetClient(),
+		Scheme: mgr.GetScheme(),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "GearLinkage")
+		os.Exit(1)
+	}
+	if err = (&controllers.LandingGearReconciler{
+		Client: mgr.GetClient(),
+		Scheme: mgr.GetScheme(),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "LandingGear")
+		os.Exit(1)
+	}
+	if err = (&controllers.AirplanePhysicsReconciler{
+		Client: mgr.GetClient(),
+		Scheme: mgr.GetScheme(),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "AirplanePhysics")
+		os.Exit(1)
+	}
+	if err = (&controllers.SimClockReconciler{
+		Client: mgr.GetClient(),
+		Scheme: mgr.GetScheme(),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "SimClock")
+		os.Exit(1)
+	}
+	if err = (&controllers.LinkageReconciler{
+		Client: mgr.GetClient(),
+		Scheme: mgr.GetScheme(),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "Linkage")
+		os.Exit(1)
+	}
+	if os.Getenv("ENABLE_WEBHOOKS") != "false" {
+		if err = (&playv1alpha1.Rudder{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "Rudder")
+			os.Exit(1)
+		}
+		if err = (&playv1alpha1.Pedals{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "Pedals")
+			os.Exit(1)
+		}
+		if err = (&playv1alpha1.Airplane{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "Airplane")
+			os.Exit(1)
+		}
+		if err = (&playv1alpha1.Registration{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "Registration")
+			os.Exit(1)
+		}
+	}
+	// v1beta1 is the storage version of these kinds, so the API server
+	// needs the conversion webhooks to serve v1alpha1 at all.  They stay on
+	// when ENABLE_WEBHOOKS turns the admission webhooks off.
+	if err = (&playv1beta1.Rudder{}).SetupWebhookWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create conversion webhook", "webhook", "Rudder")
+		os.Exit(1)
+	}
+	if err = (&playv1beta1.Pedals{}).SetupWebhookWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create conversion webhook", "webhook", "Pedals")
+		os.Exit(1)
+	}
+	if err = (&playv1beta1.Airplane{}).SetupWebhookWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create conversion webhook", "webhook", "Airplane")
+		os.Exit(1)
+	}
+	// The recordings are served next to the metrics, without any
+	// authorization of their own.  Serve them only when kube-rbac-proxy
+	// guards the metrics listener, which is then bound to localhost.
+	serveRecordings := isLoopback(metricsAddr)
+	if !serveRecordings {
+		setupLog.Info("not serving flight data, the metrics endpoint is not bound to localhost", "path", controllers.FlightDataPath)
+	}
+	if err = (&controllers.FlightDataRecorderReconciler{
+		Client:          mgr.GetClient(),
+		Scheme:          mgr.GetScheme(),
+		APIReader:       mgr.GetAPIReader(),
+		ServeRecordings: serveRecordings,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "FlightDataRecorder")
+		os.Exit(1)
+	}
+	if err = (&controllers.ReplayReconciler{
+		Client:    mgr.GetClient(),
+		Scheme:    mgr.GetScheme(),
+		APIReader: mgr.GetAPIReader(),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "Replay")
 		os.Exit(1)
 	}
 	//+kubebuilder:scaffold:builder
@@ -127,3 +275,16 @@ func main() {
 		os.Exit(1)
 	}
 }
+
+// isLoopback reports whether a listen address is bound to localhost only.
+func isLoopback(addr string) bool {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return false
+	}
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
@@ -0,0 +1,157 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package flightdata is the flight data recorder's journal.  A recording is
+// a bounded ring of timestamped samples of an airplane's controls and
+// state, oldest first.  Once the ring is full, each new sample pushes out
+// the oldest one.
+//
+// Recordings are kept as JSON, so the same format can be read back by
+// anything that wants to look at, or replay, a flight.
+package flightdata
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// DefaultCapacity is how many samples a recording holds when nobody says
+// otherwise.
+const DefaultCapacity = 1000
+
+// Sample is the state of an airplane's controls at one moment.
+type Sample struct {
+	// Time is the simulation time the sample was taken.
+	Time time.Time `json:"time"`
+
+	// Pressed is which rudder pedal is pressed.
+	Pressed string `json:"pressed,omitempty"`
+
+	// PedalTravel is the pedal travel, in percent.  Negative is left.
+	PedalTravel int32 `json:"pedalTravel"`
+
+	// RudderCommanded is the deflection, in degrees, that the rudder was
+	// told to move to.
+	RudderCommanded int32 `json:"rudderCommanded"`
+
+	// RudderDeflection is the rudder's actual deflection, in degrees.
+	RudderDeflection int32 `json:"rudderDeflection"`
+
+	// Roll is how far the yoke is turned, in percent.  Negative is left.
+	Roll int32 `json:"roll,omitempty"`
+
+	// Pitch is how far the yoke is pulled back, in percent.  Negative is
+	// forward.
+	Pitch int32 `json:"pitch,omitempty"`
+
+	// Trim is the trim wheel setting, in percent.  Negative is nose down.
+	Trim int32 `json:"trim,omitempty"`
+
+	// FlapLever is the flap lever's detent, in degrees.
+	FlapLever int32 `json:"flapLever,omitempty"`
+
+	// GearLever is where the gear lever is placed, up or down.
+	GearLever string `json:"gearLever,omitempty"`
+
+	// Airspeed is the airplane's airspeed, in knots.
+	Airspeed int32 `json:"airspeed,omitempty"`
+
+	// Gear is the state of the landing gear.
+	Gear string `json:"gear,omitempty"`
+
+	// Ready is whether the airplane was ready to fly.
+	Ready bool `json:"ready"`
+}
+
+// SameAs reports whether two samples record the same state, whenever they
+// were taken.
+func (s Sample) SameAs(other Sample) bool {
+	s.Time = other.Time
+	return s == other
+}
+
+// Recording is a bounded ring of samples, oldest first.
+type Recording struct {
+	// Capacity is the most samples the recording holds.
+	Capacity int `json:"capacity"`
+
+	// Samples are the samples in the recording, oldest first.
+	Samples []Sample `json:"samples"`
+}
+
+// New returns an empty recording that holds up to capacity samples.  A
+// capacity that isn't positive means the default.
+func New(capacity int) *Recording {
+	if capacity <= 0 {
+		capacity = DefaultCapacity
+	}
+	return &Recording{Capacity: capacity, Samples: []Sample{}}
+}
+
+// Parse reads a recording that was written by Encode.
+func Parse(data []byte) (*Recording, error) {
+	recording := &Recording{}
+	if err := json.Unmarshal(data, recording); err != nil {
+		return nil, err
+	}
+	if recording.Capacity <= 0 {
+		recording.Capacity = DefaultCapacity
+	}
+	return recording, nil
+}
+
+// Encode writes the recording as JSON.
+func (r *Recording) Encode() ([]byte, error) {
+	return json.Marshal(r)
+}
+
+// Last returns the newest sample, and whether there was one.
+func (r *Recording) Last() (Sample, bool) {
+	if len(r.Samples) == 0 {
+		return Sample{}, false
+	}
+	return r.Samples[len(r.Samples)-1], true
+}
+
+// Append adds a sample to the recording, unless it records the same state
+// as the newest sample.  The oldest samples are dropped to make room.  It
+// reports whether the sample was added.
+func (r *Recording) Append(sample Sample) bool {
+	if last, ok := r.Last(); ok && last.SameAs(sample) {
+		return false
+	}
+	r.Samples = append(r.Samples, sample)
+	if extra := len(r.Samples) - r.Capacity; extra > 0 {
+		r.Samples = append([]Sample{}, r.Samples[extra:]...)
+	}
+	return true
+}
+
+// Between returns the samples taken from one time up to and including
+// another.  A zero time leaves that end of the range open.
+func (r *Recording) Between(from, to time.Time) []Sample {
+	samples := []Sample{}
+	for _, sample := range r.Samples {
+		if !from.IsZero() && sample.Time.Before(from) {
+			continue
+		}
+		if !to.IsZero() && sample.Time.After(to) {
+			continue
+		}
+		samples = append(samples, sample)
+	}
+	return samples
+}
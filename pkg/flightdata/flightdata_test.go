@@ -0,0 +1,137 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package flightdata
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Flight Data Recordings", func() {
+
+	start := time.Date(2022, time.July, 4, 12, 0, 0, 0, time.UTC)
+	at := func(seconds int) time.Time {
+		return start.Add(time.Duration(seconds) * time.Second)
+	}
+
+	It("Drops the oldest samples once it is full", func() {
+		recording := New(3)
+		for i := 0; i < 5; i++ {
+			Expect(recording.Append(Sample{Time: at(i), PedalTravel: int32(i)})).To(BeTrue())
+		}
+		Expect(recording.Samples).To(HaveLen(3))
+		Expect(recording.Samples[0].PedalTravel).To(Equal(int32(2)))
+		last, ok := recording.Last()
+		Expect(ok).To(BeTrue())
+		Expect(last.PedalTravel).To(Equal(int32(4)))
+	})
+
+	It("Skips samples that record nothing new", func() {
+		recording := New(0)
+		Expect(recording.Capacity).To(Equal(DefaultCapacity))
+		Expect(recording.Append(Sample{Time: at(0), Pressed: "left"})).To(BeTrue())
+		Expect(recording.Append(Sample{Time: at(1), Pressed: "left"})).To(BeFalse())
+		Expect(recording.Append(Sample{Time: at(2), Pressed: "right"})).To(BeTrue())
+		Expect(recording.Samples).To(HaveLen(2))
+	})
+
+	It("Returns the samples in a time range", func() {
+		recording := New(10)
+		for i := 0; i < 5; i++ {
+			recording.Append(Sample{Time: at(i), PedalTravel: int32(i)})
+		}
+		Expect(recording.Between(at(1), at(3))).To(HaveLen(3))
+		Expect(recording.Between(at(3), time.Time{})).To(HaveLen(2))
+		Expect(recording.Between(time.Time{}, time.Time{})).To(HaveLen(5))
+		Expect(recording.Between(at(6), at(9))).To(BeEmpty())
+	})
+
+	It("Reads back what it wrote", func() {
+		recording := New(2)
+		recording.Append(Sample{Time: at(0), Pressed: "left", RudderCommanded: -25, Ready: true})
+		data, err := recording.Encode()
+		Expect(err).NotTo(HaveOccurred())
+
+		back, err := Parse(data)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(back.Capacity).To(Equal(2))
+		Expect(back.Samples).To(HaveLen(1))
+		Expect(back.Samples[0].Time.Equal(at(0))).To(BeTrue())
+		Expect(back.Samples[0].SameAs(recording.Samples[0])).To(BeTrue())
+	})
+})
+
+var _ = Describe("Timelines", func() {
+
+	It("Reads a CSV timeline with offsets", func() {
+		samples, err := ParseTimeline("takeoff.csv", []byte("time,pressed,rudderDeflection\n2s,right,25\n0s,left,0\n1.5s, left, -25\n"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(samples).To(HaveLen(3))
+		Expect(samples[0].Time).To(Equal(Origin))
+		Expect(samples[1].Time).To(Equal(Origin.Add(1500 * time.Millisecond)))
+		Expect(samples[1].Pressed).To(Equal("left"))
+		Expect(samples[1].RudderDeflection).To(Equal(int32(-25)))
+		Expect(samples[2].Pressed).To(Equal("right"))
+	})
+
+	It("Reads the inputs for every control", func() {
+		samples, err := ParseTimeline("approach.csv", []byte("time,roll,pitch,trim,flapLever,gearLever\n0s,-20,10,5,20,down\n"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(samples).To(HaveLen(1))
+		Expect(samples[0].Roll).To(Equal(int32(-20)))
+		Expect(samples[0].Pitch).To(Equal(int32(10)))
+		Expect(samples[0].Trim).To(Equal(int32(5)))
+		Expect(samples[0].FlapLever).To(Equal(int32(20)))
+		Expect(samples[0].GearLever).To(Equal("down"))
+	})
+
+	It("Reads a recording or a list of samples as JSON", func() {
+		recording := New(10)
+		recording.Append(Sample{Time: Origin, Pressed: "left"})
+		data, err := recording.Encode()
+		Expect(err).NotTo(HaveOccurred())
+		samples, err := ParseTimeline("flightdata.json", data)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(samples).To(HaveLen(1))
+
+		samples, err = ParseTimeline("inputs.json", []byte(`[{"time":"2022-07-04T12:00:00Z","pressed":"right"}]`))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(samples).To(HaveLen(1))
+		Expect(samples[0].Pressed).To(Equal("right"))
+	})
+
+	DescribeTable("rejects bad timelines",
+		func(name string, data string) {
+			_, err := ParseTimeline(name, []byte(data))
+			Expect(err).To(HaveOccurred())
+		},
+		Entry("no time column", "a.csv", "pressed\nleft\n"),
+		Entry("an unknown column", "a.csv", "time,yaw\n0s,3\n"),
+		Entry("a bad time", "a.csv", "time\nsoon\n"),
+		Entry("a bad number", "a.csv", "time,pedalTravel\n0s,lots\n"),
+		Entry("no samples", "a.json", "[]"),
+		Entry("not JSON", "a.json", "{"),
+		Entry("a pedal that doesn't exist", "a.csv", "time,pressed\n0s,hard-left\n"),
+		Entry("too much pedal travel", "a.csv", "time,pedalTravel\n0s,150\n"),
+		Entry("a flap lever between detents", "a.csv", "time,flapLever\n0s,15\n"),
+		Entry("a gear lever that isn't up or down", "a.csv", "time,gearLever\n0s,sideways\n"),
+		Entry("too much roll", "a.csv", "time,roll\n0s,-101\n"),
+		Entry("a pedal that doesn't exist in JSON", "a.json", `[{"time":"2022-01-01T00:00:00Z","pressed":"hard-left"}]`),
+	)
+})
@@ -0,0 +1,232 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package flightdata
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Origin is the time that a timeline's offsets are counted from, when its
+// samples give offsets rather than times.
+var Origin = time.Unix(0, 0).UTC()
+
+// ParseTimeline reads a timeline of samples, oldest first.  A name ending
+// in ".csv" is read as CSV, anything else as JSON.
+//
+// The JSON is either a recording, as the flight data recorder keeps it, or
+// a bare list of samples.
+//
+// The CSV has a header row naming its columns after the JSON fields of a
+// sample, such as
+//
+//	time,pressed,rudderDeflection
+//	0s,left,0
+//	2s,left,-25
+//
+// Only the time column is required.  A time is either an RFC 3339 time or
+// an offset, like "1.5s", from the start of the timeline.  Inputs the
+// controls would refuse, such as a pedal that doesn't exist or a flap
+// lever between detents, make the timeline invalid.
+func ParseTimeline(name string, data []byte) ([]Sample, error) {
+	var samples []Sample
+	var err error
+	if strings.HasSuffix(name, ".csv") {
+		samples, err = parseCSV(bytes.NewReader(data))
+	} else {
+		samples, err = parseJSON(data)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(samples) == 0 {
+		return nil, fmt.Errorf("timeline %s has no samples", name)
+	}
+	sort.SliceStable(samples, func(i, j int) bool {
+		return samples[i].Time.Before(samples[j].Time)
+	})
+	return samples, nil
+}
+
+func parseJSON(data []byte) ([]Sample, error) {
+	data = bytes.TrimSpace(data)
+	samples := []Sample{}
+	if bytes.HasPrefix(data, []byte("[")) {
+		if err := json.Unmarshal(data, &samples); err != nil {
+			return nil, err
+		}
+	} else {
+		recording, err := Parse(data)
+		if err != nil {
+			return nil, err
+		}
+		samples = recording.Samples
+	}
+	for i := range samples {
+		for _, column := range inputColumns {
+			if err := checkField(&samples[i], column); err != nil {
+				return nil, fmt.Errorf("sample %d: %s: %w", i, column, err)
+			}
+		}
+	}
+	return samples, nil
+}
+
+func parseCSV(r io.Reader) ([]Sample, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+	rows, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("the CSV has no header row")
+	}
+
+	header := rows[0]
+	hasTime := false
+	for _, column := range header {
+		if column == "time" {
+			hasTime = true
+		}
+	}
+	if !hasTime {
+		return nil, fmt.Errorf("the CSV has no time column")
+	}
+
+	samples := make([]Sample, 0, len(rows)-1)
+	for i, row := range rows[1:] {
+		sample := Sample{}
+		for j, column := range header {
+			if err := setField(&sample, column, row[j]); err != nil {
+				return nil, fmt.Errorf("row %d: %s: %w", i+2, column, err)
+			}
+		}
+		samples = append(samples, sample)
+	}
+	return samples, nil
+}
+
+// setField sets the field of the sample that a CSV column names.
+func setField(sample *Sample, column string, value string) error {
+	value = strings.TrimSpace(value)
+	var err error
+	switch column {
+	case "time":
+		sample.Time, err = parseTime(value)
+	case "pressed":
+		sample.Pressed = value
+	case "pedalTravel":
+		sample.PedalTravel, err = parseInt(value)
+	case "rudderCommanded":
+		sample.RudderCommanded, err = parseInt(value)
+	case "rudderDeflection":
+		sample.RudderDeflection, err = parseInt(value)
+	case "roll":
+		sample.Roll, err = parseInt(value)
+	case "pitch":
+		sample.Pitch, err = parseInt(value)
+	case "trim":
+		sample.Trim, err = parseInt(value)
+	case "flapLever":
+		sample.FlapLever, err = parseInt(value)
+	case "gearLever":
+		sample.GearLever = value
+	case "airspeed":
+		sample.Airspeed, err = parseInt(value)
+	case "gear":
+		sample.Gear = value
+	case "ready":
+		if len(value) > 0 {
+			sample.Ready, err = strconv.ParseBool(value)
+		}
+	default:
+		return fmt.Errorf("unknown column")
+	}
+	if err != nil {
+		return err
+	}
+	return checkField(sample, column)
+}
+
+// inputColumns are the columns that hold inputs to the controls, which the
+// controls will only accept within their limits.
+var inputColumns = []string{"pressed", "pedalTravel", "roll", "pitch", "trim", "flapLever", "gearLever"}
+
+// checkField checks that the input a column holds is one the control would
+// accept.  Other columns are always fine.
+func checkField(sample *Sample, column string) error {
+	switch column {
+	case "pressed":
+		switch sample.Pressed {
+		case "", "none", "left", "right":
+			return nil
+		}
+		return fmt.Errorf("%q is not none, left or right", sample.Pressed)
+	case "pedalTravel":
+		return checkTravel(sample.PedalTravel)
+	case "roll":
+		return checkTravel(sample.Roll)
+	case "pitch":
+		return checkTravel(sample.Pitch)
+	case "trim":
+		return checkTravel(sample.Trim)
+	case "flapLever":
+		switch sample.FlapLever {
+		case 0, 10, 20, 30:
+			return nil
+		}
+		return fmt.Errorf("%d is not a detent of 0, 10, 20 or 30", sample.FlapLever)
+	case "gearLever":
+		switch sample.GearLever {
+		case "", "up", "down":
+			return nil
+		}
+		return fmt.Errorf("%q is not up or down", sample.GearLever)
+	}
+	return nil
+}
+
+// checkTravel checks that a travel is within full travel either way.
+func checkTravel(value int32) error {
+	if value < -100 || value > 100 {
+		return fmt.Errorf("%d is outside -100 to 100", value)
+	}
+	return nil
+}
+
+func parseTime(value string) (time.Time, error) {
+	if offset, err := time.ParseDuration(value); err == nil {
+		return Origin.Add(offset), nil
+	}
+	return time.Parse(time.RFC3339Nano, value)
+}
+
+func parseInt(value string) (int32, error) {
+	if len(value) == 0 {
+		return 0, nil
+	}
+	n, err := strconv.ParseInt(value, 10, 32)
+	return int32(n), err
+}
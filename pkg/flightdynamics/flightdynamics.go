@@ -0,0 +1,357 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package flightdynamics is a point-mass flight model.  It integrates the
+// forces on an airplane along its flight path and turns the deflections of
+// the control surfaces into changes of attitude, heading and airspeed.
+//
+// The model works in SI units: meters, kilograms, seconds and newtons.
+// Angles are in degrees, because that is what the control surfaces report.
+package flightdynamics
+
+import (
+	"math"
+	"time"
+)
+
+const (
+	// Gravity is standard gravity in meters per second squared.
+	Gravity = 9.80665
+
+	// SeaLevelDensity is the density of the standard atmosphere at sea
+	// level in kilograms per cubic meter.
+	SeaLevelDensity = 1.225
+
+	// StepSize is the interval of a single integration step.
+	StepSize = 20 * time.Millisecond
+
+	// maxBank keeps the coordinated turn away from the vertical, where the
+	// point-mass model no longer makes sense.
+	maxBank = 80.0
+
+	// minAirspeed avoids dividing by zero while the airplane is barely
+	// moving.
+	minAirspeed = 1.0
+)
+
+// Aircraft describes the airframe and engine.
+type Aircraft struct {
+	// Mass is the gross weight in kilograms.
+	Mass float64
+
+	// WingArea is the reference wing area in square meters.
+	WingArea float64
+
+	// MaxThrust is the static thrust at full throttle in newtons.
+	MaxThrust float64
+
+	// PropellerSpeed is the airspeed, in meters per second, at which the
+	// propeller no longer produces thrust.
+	PropellerSpeed float64
+
+	// LiftCoefficient is the lift coefficient at zero angle of attack.
+	LiftCoefficient float64
+
+	// LiftSlope is the change in lift coefficient per radian of angle of
+	// attack.
+	LiftSlope float64
+
+	// StallAngle is the critical angle of attack in degrees.
+	StallAngle float64
+
+	// ParasiteDrag is the zero-lift drag coefficient.
+	ParasiteDrag float64
+
+	// InducedDrag is the induced drag factor, the drag coefficient added
+	// per squared lift coefficient.
+	InducedDrag float64
+
+	// GearDrag is the drag coefficient added by extended landing gear.
+	GearDrag float64
+
+	// MaxFlaps is the flap extension in degrees that gives the full flap
+	// lift and drag.
+	MaxFlaps float64
+
+	// FlapLift is the lift coefficient added by full flaps.
+	FlapLift float64
+
+	// FlapDrag is the drag coefficient added by full flaps.
+	FlapDrag float64
+
+	// TrimAngle is the angle of attack, in degrees, that the airplane
+	// settles at with the elevator at neutral.
+	TrimAngle float64
+
+	// ElevatorPower is the change in angle of attack per degree of
+	// elevator deflection.
+	ElevatorPower float64
+
+	// PitchResponse is how long, in seconds, the airplane takes to settle
+	// at a new angle of attack.
+	PitchResponse float64
+
+	// RollPower is the roll rate, in degrees per second, per degree of
+	// aileron deflection at the reference airspeed.
+	RollPower float64
+
+	// YawPower is the turn rate, in degrees per second, per degree of
+	// rudder deflection at the reference airspeed.
+	YawPower float64
+
+	// SteeringPower is the turn rate on the ground, in degrees per second,
+	// per degree of rudder deflection.  The nose wheel is steered with the
+	// rudder pedals.
+	SteeringPower float64
+
+	// ReferenceSpeed is the airspeed, in meters per second, at which the
+	// control powers are given.
+	ReferenceSpeed float64
+
+	// RollingFriction is the coefficient of rolling friction of the
+	// wheels on the runway.
+	RollingFriction float64
+
+	// MaxGroundPitch is the highest pitch attitude, in degrees, before the
+	// tail strikes the runway.
+	MaxGroundPitch float64
+}
+
+// DefaultAircraft returns a light, single-engine airplane with retractable
+// gear.
+func DefaultAircraft() Aircraft {
+	return Aircraft{
+		Mass:            1100,
+		WingArea:        16.2,
+		MaxThrust:       3000,
+		PropellerSpeed:  100,
+		LiftCoefficient: 0.3,
+		LiftSlope:       5.0,
+		StallAngle:      15,
+		ParasiteDrag:    0.027,
+		InducedDrag:     0.054,
+		GearDrag:        0.008,
+		MaxFlaps:        30,
+		FlapLift:        0.5,
+		FlapDrag:        0.03,
+		TrimAngle:       2,
+		ElevatorPower:   0.5,
+		PitchResponse:   0.5,
+		RollPower:       3,
+		YawPower:        0.2,
+		SteeringPower:   1,
+		ReferenceSpeed:  50,
+		RollingFriction: 0.02,
+		MaxGroundPitch:  12,
+	}
+}
+
+// Controls are the current positions of the control surfaces and engine.
+type Controls struct {
+	// Throttle is the engine power, from 0 to 1.
+	Throttle float64
+
+	// LeftAileron and RightAileron are the aileron deflections in degrees.
+	// Positive values move the trailing edge down.
+	LeftAileron  float64
+	RightAileron float64
+
+	// Elevator is the elevator deflection in degrees.  Positive values move
+	// the trailing edge up, raising the nose.
+	Elevator float64
+
+	// Rudder is the rudder deflection in degrees.  Positive values are to
+	// the right.
+	Rudder float64
+
+	// Flaps is the flap extension in degrees.
+	Flaps float64
+
+	// GearDown is set when the landing gear is not retracted.
+	GearDown bool
+}
+
+// State is the position and motion of the airplane.
+type State struct {
+	// North and East are the distance, in meters, from where the airplane
+	// started.
+	North float64
+	East  float64
+
+	// Altitude is the height above the runway in meters.
+	Altitude float64
+
+	// Heading is the direction of flight in degrees, from 0 up to 360.
+	Heading float64
+
+	// Pitch is the attitude of the nose above the horizon in degrees.
+	Pitch float64
+
+	// Roll is the bank angle in degrees.  Positive values are right wing
+	// down.
+	Roll float64
+
+	// AngleOfAttack is the angle between the wing and the flight path in
+	// degrees.
+	AngleOfAttack float64
+
+	// FlightPath is the climb angle of the flight path in degrees.
+	FlightPath float64
+
+	// Airspeed is the true airspeed in meters per second.
+	Airspeed float64
+
+	// OnGround is set while the airplane rests on its wheels.
+	OnGround bool
+}
+
+// VerticalSpeed returns the rate of climb in meters per second.
+func (s State) VerticalSpeed() float64 {
+	return s.Airspeed * math.Sin(radians(s.FlightPath))
+}
+
+// IndicatedAirspeed returns the airspeed that the airspeed indicator shows,
+// in meters per second.  It is lower than the true airspeed in the thinner
+// air at altitude.
+func (s State) IndicatedAirspeed() float64 {
+	return s.Airspeed * math.Sqrt(Density(s.Altitude)/SeaLevelDensity)
+}
+
+// Density returns the density of the standard atmosphere, in kilograms per
+// cubic meter, at the given altitude in meters.  The formula runs out of air
+// at about 44 km, and the density is zero from there up.
+func Density(altitude float64) float64 {
+	if altitude < 0 {
+		altitude = 0
+	}
+	base := 1 - 2.25577e-5*altitude
+	if base <= 0 {
+		return 0
+	}
+	return SeaLevelDensity * math.Pow(base, 4.2559)
+}
+
+// Simulate advances the state by the elapsed time, in whole steps of
+// StepSize.  Any remainder shorter than a step is left for the next call.
+func Simulate(aircraft Aircraft, state State, controls Controls, elapsed time.Duration) State {
+	for ; elapsed >= StepSize; elapsed -= StepSize {
+		state = Step(aircraft, state, controls, StepSize.Seconds())
+	}
+	return state
+}
+
+// Step integrates the state forward by dt seconds.
+func Step(aircraft Aircraft, state State, controls Controls, dt float64) State {
+	a := &aircraft
+	s := state
+
+	flaps := clamp(controls.Flaps/a.MaxFlaps, 0, 1)
+	throttle := clamp(controls.Throttle, 0, 1)
+
+	// The airplane is statically stable, so the elevator sets the angle of
+	// attack it settles at rather than a pitch rate.
+	target := a.TrimAngle + a.ElevatorPower*controls.Elevator
+	s.AngleOfAttack += (target - s.AngleOfAttack) * clamp(dt/a.PitchResponse, 0, 1)
+	if s.OnGround {
+		s.AngleOfAttack = clamp(s.AngleOfAttack, 0, a.MaxGroundPitch)
+	}
+
+	q := 0.5 * Density(s.Altitude) * s.Airspeed * s.Airspeed
+	cl := liftCoefficient(a, s.AngleOfAttack) + flaps*a.FlapLift
+	cd := a.ParasiteDrag + a.InducedDrag*cl*cl + flaps*a.FlapDrag
+	if controls.GearDown {
+		cd += a.GearDrag
+	}
+	lift := q * a.WingArea * cl
+	drag := q * a.WingArea * cd
+	thrust := throttle * a.MaxThrust * math.Max(0, 1-s.Airspeed/a.PropellerSpeed)
+	weight := a.Mass * Gravity
+
+	// Control surfaces lose authority as the airflow over them slows down.
+	authority := clamp(s.Airspeed/a.ReferenceSpeed, 0, 1.5)
+
+	if s.OnGround && lift < weight {
+		friction := a.RollingFriction * (weight - lift)
+		accel := (thrust - drag) / a.Mass
+		if s.Airspeed > 0 || accel > 0 {
+			accel -= friction / a.Mass
+		}
+		s.Airspeed = math.Max(0, s.Airspeed+accel*dt)
+		s.Heading += controls.Rudder * a.SteeringPower * clamp(s.Airspeed/10, 0, 1) * dt
+		s.Roll = 0
+		s.FlightPath = 0
+		s.Altitude = 0
+	} else {
+		s.OnGround = false
+		speed := math.Max(s.Airspeed, minAirspeed)
+		gamma := radians(s.FlightPath)
+		phi := radians(s.Roll)
+
+		// Positive roll input raises the right aileron and lowers the
+		// left, rolling to the right.
+		roll := (controls.LeftAileron - controls.RightAileron) / 2
+		s.Roll = clamp(s.Roll+roll*a.RollPower*authority*dt, -maxBank, maxBank)
+
+		turn := degrees(Gravity*math.Tan(phi)/speed) + controls.Rudder*a.YawPower*authority
+		s.Heading += turn * dt
+
+		accel := (thrust-drag)/a.Mass - Gravity*math.Sin(gamma)
+		climb := (lift*math.Cos(phi) - weight*math.Cos(gamma)) / (a.Mass * speed)
+		s.Airspeed = math.Max(0, s.Airspeed+accel*dt)
+		s.FlightPath = clamp(s.FlightPath+degrees(climb*dt), -90, 90)
+		s.Altitude += speed * math.Sin(radians(s.FlightPath)) * dt
+
+		if s.Altitude <= 0 && s.FlightPath <= 0 {
+			// Touchdown.
+			s.Altitude = 0
+			s.FlightPath = 0
+			s.Roll = 0
+			s.OnGround = true
+		}
+	}
+
+	s.Heading = math.Mod(s.Heading+360, 360)
+	s.Pitch = s.FlightPath + s.AngleOfAttack
+
+	ground := s.Airspeed * math.Cos(radians(s.FlightPath)) * dt
+	s.North += ground * math.Cos(radians(s.Heading))
+	s.East += ground * math.Sin(radians(s.Heading))
+
+	return s
+}
+
+// liftCoefficient returns the lift coefficient of the clean wing.  Past the
+// stall the wing quickly loses lift.
+func liftCoefficient(a *Aircraft, alpha float64) float64 {
+	if alpha <= a.StallAngle {
+		return a.LiftCoefficient + a.LiftSlope*radians(alpha)
+	}
+	peak := a.LiftCoefficient + a.LiftSlope*radians(a.StallAngle)
+	return peak * math.Max(0.4, 1-(alpha-a.StallAngle)/20)
+}
+
+// clamp limits value to the range [min, max].
+func clamp(value, min, max float64) float64 {
+	return math.Max(min, math.Min(max, value))
+}
+
+func radians(deg float64) float64 {
+	return deg * math.Pi / 180
+}
+
+func degrees(rad float64) float64 {
+	return rad * 180 / math.Pi
+}
@@ -0,0 +1,119 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package flightdynamics
+
+import (
+	"math"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Flight Dynamics", func() {
+
+	var aircraft Aircraft
+
+	BeforeEach(func() {
+		aircraft = DefaultAircraft()
+	})
+
+	// cruise is straight and level-ish flight at a comfortable altitude.
+	cruise := func() State {
+		return State{
+			Altitude: 1000,
+			Airspeed: 50,
+		}
+	}
+
+	It("Stays put with the engine at idle", func() {
+		state := Simulate(aircraft, State{OnGround: true}, Controls{GearDown: true}, 10*time.Second)
+		Expect(state.OnGround).To(BeTrue())
+		Expect(state.Airspeed).To(BeZero())
+		Expect(state.North).To(BeZero())
+	})
+
+	It("Takes off with full power and the yoke back", func() {
+		controls := Controls{Throttle: 1, Elevator: 10, GearDown: true}
+		state := Simulate(aircraft, State{OnGround: true}, controls, 5*time.Second)
+		Expect(state.OnGround).To(BeTrue())
+		Expect(state.Airspeed).To(BeNumerically(">", 5))
+
+		state = Simulate(aircraft, state, controls, 60*time.Second)
+		Expect(state.OnGround).To(BeFalse())
+		Expect(state.Altitude).To(BeNumerically(">", 30))
+		Expect(state.VerticalSpeed()).To(BeNumerically(">", 0))
+		Expect(state.North).To(BeNumerically(">", 0))
+	})
+
+	It("Glides down with the engine at idle", func() {
+		state := Simulate(aircraft, cruise(), Controls{}, 30*time.Second)
+		Expect(state.Altitude).To(BeNumerically("<", 1000))
+		Expect(state.Airspeed).To(BeNumerically(">", 20))
+	})
+
+	It("Lands when it reaches the ground", func() {
+		start := cruise()
+		start.Altitude = 10
+		start.FlightPath = -5
+		state := Simulate(aircraft, start, Controls{GearDown: true}, 10*time.Second)
+		Expect(state.OnGround).To(BeTrue())
+		Expect(state.Altitude).To(BeZero())
+	})
+
+	DescribeTable("Turns with the ailerons",
+		func(left, right float64, turnRight bool) {
+			controls := Controls{Throttle: 0.6, LeftAileron: left, RightAileron: right}
+			state := Simulate(aircraft, cruise(), controls, time.Second)
+			// Center the ailerons and let the bank turn the airplane.
+			controls.LeftAileron, controls.RightAileron = 0, 0
+			state = Simulate(aircraft, state, controls, 5*time.Second)
+			if turnRight {
+				Expect(state.Roll).To(BeNumerically(">", 0))
+				Expect(state.Heading).To(BeNumerically(">", 0))
+				Expect(state.Heading).To(BeNumerically("<", 180))
+			} else {
+				Expect(state.Roll).To(BeNumerically("<", 0))
+				Expect(state.Heading).To(BeNumerically(">", 180))
+			}
+		},
+		Entry("to the right", 10.0, -10.0, true),
+		Entry("to the left", -10.0, 10.0, false),
+	)
+
+	It("Loses lift past the stall", func() {
+		beyond := liftCoefficient(&aircraft, aircraft.StallAngle+10)
+		peak := liftCoefficient(&aircraft, aircraft.StallAngle)
+		Expect(beyond).To(BeNumerically("<", peak))
+	})
+
+	It("Shows a lower indicated airspeed at altitude", func() {
+		state := State{Altitude: 3000, Airspeed: 50}
+		Expect(state.IndicatedAirspeed()).To(BeNumerically("<", state.Airspeed))
+	})
+
+	It("Runs out of air above the standard atmosphere", func() {
+		Expect(Density(44000)).To(BeNumerically(">", 0))
+		Expect(Density(50000)).To(BeZero())
+
+		state := State{Altitude: 50000, Airspeed: 50}
+		Expect(state.IndicatedAirspeed()).To(BeZero())
+		state = Step(aircraft, state, Controls{}, 0.1)
+		Expect(math.IsNaN(state.Altitude)).To(BeFalse())
+		Expect(math.IsNaN(state.Airspeed)).To(BeFalse())
+	})
+})
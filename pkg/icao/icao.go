@@ -0,0 +1,164 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package icao converts between United States N-numbers and the 24-bit
+// Mode S addresses that the ICAO allocated to the United States.
+//
+// The FAA hands out the block A00001 to ADF7C7 in N-number order, so the
+// address of an airplane can be worked out from its N-number alone.  The
+// N-numbers are laid out as a tree: after each digit come the marks that
+// end there with one or two letters, and then the marks that go on with
+// another digit.
+package icao
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	// First is the address of N1, the first N-number.
+	First uint32 = 0xA00001
+
+	// Last is the address of N99999, the last N-number.
+	Last uint32 = 0xADF7C7
+)
+
+// N-numbers never use I or O.
+const (
+	letters = "ABCDEFGHJKLMNPQRSTUVWXYZ"
+	digits  = "0123456789"
+)
+
+// The size of each part of the tree.  A suffix is no letters, one letter, or
+// two letters.  The fifth character can be any letter or digit.
+const (
+	suffixSize  = 1 + len(letters)*(1+len(letters))
+	bucket4Size = 1 + len(letters) + len(digits)
+	bucket3Size = len(digits)*bucket4Size + suffixSize
+	bucket2Size = len(digits)*bucket3Size + suffixSize
+	bucket1Size = len(digits)*bucket2Size + suffixSize
+)
+
+// Address returns the Mode S address of an N-number.  The N-number must be
+// valid under the FAA's rules.
+func Address(nNumber string) (uint32, error) {
+	mark := strings.ToUpper(nNumber)
+	if !strings.HasPrefix(mark, "N") || len(mark) < 2 || len(mark) > 6 || mark[1] < '1' || mark[1] > '9' {
+		return 0, fmt.Errorf("%q is not an N-number", nNumber)
+	}
+	mark = mark[1:]
+
+	offset := int(mark[0]-'1') * bucket1Size
+	for i := 1; i < len(mark); i++ {
+		c := mark[i]
+		if i == 4 {
+			// The last character may be a letter or a digit.
+			n := strings.IndexByte(letters+digits, c)
+			if n < 0 {
+				return 0, fmt.Errorf("%q is not an N-number", nNumber)
+			}
+			offset += n + 1
+			break
+		}
+		if strings.IndexByte(letters, c) >= 0 {
+			suffix, err := suffixOffset(mark[i:])
+			if err != nil {
+				return 0, fmt.Errorf("%q is not an N-number: %w", nNumber, err)
+			}
+			offset += suffix
+			break
+		}
+		if c < '0' || c > '9' {
+			return 0, fmt.Errorf("%q is not an N-number", nNumber)
+		}
+		offset += int(c-'0')*[]int{0, bucket2Size, bucket3Size, bucket4Size}[i] + suffixSize
+	}
+
+	return First + uint32(offset), nil
+}
+
+// NNumber returns the N-number that has the given Mode S address.
+func NNumber(address uint32) (string, error) {
+	if address < First || address > Last {
+		return "", fmt.Errorf("%06X is not a United States address", address)
+	}
+	offset := int(address - First)
+
+	var mark strings.Builder
+	mark.WriteByte('N')
+	mark.WriteByte(byte('1' + offset/bucket1Size))
+	offset %= bucket1Size
+	for _, size := range []int{bucket2Size, bucket3Size, bucket4Size} {
+		if offset < suffixSize {
+			mark.WriteString(suffix(offset))
+			return mark.String(), nil
+		}
+		offset -= suffixSize
+		mark.WriteByte(byte('0' + offset/size))
+		offset %= size
+	}
+	if offset > 0 {
+		mark.WriteByte((letters + digits)[offset-1])
+	}
+	return mark.String(), nil
+}
+
+// Hex formats an address the way transponders and ADS-B receivers show it.
+func Hex(address uint32) string {
+	return fmt.Sprintf("%06X", address)
+}
+
+// ParseHex reads an address written as six hexadecimal digits.
+func ParseHex(s string) (uint32, error) {
+	address, err := strconv.ParseUint(s, 16, 24)
+	if err != nil {
+		return 0, fmt.Errorf("%q is not a Mode S address: %w", s, err)
+	}
+	return uint32(address), nil
+}
+
+// suffixOffset returns the position of a one or two letter suffix among the
+// suffixes that can end an N-number.
+func suffixOffset(s string) (int, error) {
+	if len(s) > 2 {
+		return 0, fmt.Errorf("too many letters")
+	}
+	first := strings.IndexByte(letters, s[0])
+	offset := (len(letters)+1)*first + 1
+	if len(s) == 2 {
+		second := strings.IndexByte(letters, s[1])
+		if second < 0 {
+			return 0, fmt.Errorf("a digit after a letter")
+		}
+		offset += second + 1
+	}
+	return offset, nil
+}
+
+// suffix returns the suffix at the given position.
+func suffix(offset int) string {
+	if offset == 0 {
+		return ""
+	}
+	first := letters[(offset-1)/(len(letters)+1)]
+	rest := (offset - 1) % (len(letters) + 1)
+	if rest == 0 {
+		return string(first)
+	}
+	return string([]byte{first, letters[rest-1]})
+}
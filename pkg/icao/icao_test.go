@@ -0,0 +1,82 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package icao
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Mode S Addresses", func() {
+
+	DescribeTable("maps N-numbers to addresses",
+		func(nNumber string, hex string) {
+			address, err := Address(nNumber)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(Hex(address)).To(Equal(hex))
+
+			back, err := NNumber(address)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(back).To(Equal(nNumber))
+		},
+		Entry("the first", "N1", "A00001"),
+		Entry("one letter", "N1A", "A00002"),
+		Entry("two letters", "N1AA", "A00003"),
+		Entry("the last two letters", "N1ZZ", "A00259"),
+		Entry("a second digit", "N10", "A0025A"),
+		Entry("the second bucket", "N2", "A18D50"),
+		Entry("the last", "N99999", "ADF7C7"),
+	)
+
+	It("Goes both ways for every address", func() {
+		for address := First; address <= Last; address++ {
+			nNumber, err := NNumber(address)
+			Expect(err).NotTo(HaveOccurred())
+			back, err := Address(nNumber)
+			Expect(err).NotTo(HaveOccurred())
+			if back != address {
+				Fail(Hex(address) + " became " + nNumber + " and then " + Hex(back))
+			}
+		}
+	})
+
+	DescribeTable("rejects what isn't an N-number",
+		func(nNumber string) {
+			_, err := Address(nNumber)
+			Expect(err).To(HaveOccurred())
+		},
+		Entry("no N", "G-ABCD"),
+		Entry("nothing after the N", "N"),
+		Entry("a leading zero", "N0123"),
+		Entry("three letters", "N1ABC"),
+		Entry("a digit after a letter", "N1A1"),
+		Entry("too long", "N123456"),
+	)
+
+	It("Rejects addresses outside the United States block", func() {
+		_, err := NNumber(First - 1)
+		Expect(err).To(HaveOccurred())
+		_, err = NNumber(Last + 1)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("Reads addresses back", func() {
+		Expect(ParseHex("a00001")).To(Equal(First))
+		_, err := ParseHex("xyz")
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -0,0 +1,352 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scenario
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	playv1alpha1 "github.com/roehrich-hpe/airplane-sim/api/v1alpha1"
+)
+
+// DefaultAssemblyTimeout is how long the runner waits for an airplane it
+// built to become ready.
+const DefaultAssemblyTimeout = 30 * time.Second
+
+// DefaultScrapTimeout is how long the runner waits for an airplane it built
+// to be torn down.  Teardown waits up to a minute for the surfaces to stow.
+const DefaultScrapTimeout = 2 * time.Minute
+
+// Runner runs scenarios against a cluster.
+type Runner struct {
+	// Client talks to the cluster.  Its scheme must know the play.github.com
+	// types.
+	Client client.Client
+
+	// Namespace is where the scenario's airplane lives.
+	Namespace string
+
+	// Poll is how often expectations are checked.  Zero means every
+	// 100 milliseconds.
+	Poll time.Duration
+
+	// AssemblyTimeout is how long to wait for an airplane that the runner
+	// built to become ready.  Zero means DefaultAssemblyTimeout.
+	AssemblyTimeout time.Duration
+
+	// ScrapTimeout is how long to wait for an airplane that the runner
+	// built to be torn down.  Zero means DefaultScrapTimeout.
+	ScrapTimeout time.Duration
+}
+
+// Result is the outcome of a scenario.
+type Result struct {
+	// Name is the scenario's name.
+	Name string
+
+	// Steps are the outcome of each step, in order.  When the runner
+	// builds the airplane, its assembly is the first step and scrapping it
+	// is the last.
+	Steps []StepResult
+
+	// Duration is how long the scenario took.
+	Duration time.Duration
+}
+
+// Failed reports whether any step of the scenario failed.
+func (r *Result) Failed() bool {
+	for _, step := range r.Steps {
+		if step.Err != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// StepResult is the outcome of a step.
+type StepResult struct {
+	// Name describes the step.
+	Name string
+
+	// Err is why the step failed, or nil if it passed.
+	Err error
+
+	// Skipped is set for the steps after a failure, which aren't run.
+	Skipped bool
+
+	// Duration is how long the step took.
+	Duration time.Duration
+}
+
+// Run runs a scenario.  Errors are reported in the result rather than
+// returned, so one broken scenario doesn't stop the rest.
+func (r *Runner) Run(ctx context.Context, scenario *Scenario) *Result {
+	began := time.Now()
+	result := &Result{Name: scenario.Name}
+	key := types.NamespacedName{Name: scenario.Airplane.Name, Namespace: r.namespace()}
+
+	failed := false
+	run := func(name string, step func() error) {
+		if failed {
+			result.Steps = append(result.Steps, StepResult{Name: name, Skipped: true})
+			return
+		}
+		started := time.Now()
+		err := step()
+		result.Steps = append(result.Steps, StepResult{Name: name, Err: err, Duration: time.Since(started)})
+		failed = err != nil
+	}
+
+	if len(scenario.Airplane.TailNumber) > 0 {
+		run("assemble airplane "+scenario.Airplane.TailNumber, func() error {
+			return r.assemble(ctx, key, &scenario.Airplane)
+		})
+	}
+	for i := range scenario.Steps {
+		step := &scenario.Steps[i]
+		run(step.Describe(), func() error {
+			return r.runStep(ctx, key, step)
+		})
+	}
+	if len(scenario.Airplane.TailNumber) > 0 {
+		// The airplane is scrapped even when a step failed.
+		started := time.Now()
+		err := r.scrap(ctx, key)
+		result.Steps = append(result.Steps, StepResult{Name: "scrap airplane " + scenario.Airplane.TailNumber, Err: err, Duration: time.Since(started)})
+	}
+
+	result.Duration = time.Since(began)
+	return result
+}
+
+func (r *Runner) namespace() string {
+	if len(r.Namespace) == 0 {
+		return corev1.NamespaceDefault
+	}
+	return r.Namespace
+}
+
+func (r *Runner) poll() time.Duration {
+	if r.Poll <= 0 {
+		return 100 * time.Millisecond
+	}
+	return r.Poll
+}
+
+// assemble builds the scenario's airplane and waits for it to be ready.
+func (r *Runner) assemble(ctx context.Context, key types.NamespacedName, spec *Airplane) error {
+	airplane := &playv1alpha1.Airplane{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      key.Name,
+			Namespace: key.Namespace,
+		},
+		Spec: playv1alpha1.AirplaneSpec{
+			TailNumber: spec.TailNumber,
+			Country:    spec.Country,
+		},
+	}
+	if len(spec.Type) > 0 {
+		airplane.Spec.TypeRef = &corev1.LocalObjectReference{Name: spec.Type}
+	}
+	if err := r.Client.Create(ctx, airplane); err != nil {
+		return err
+	}
+
+	timeout := r.AssemblyTimeout
+	if timeout <= 0 {
+		timeout = DefaultAssemblyTimeout
+	}
+	ready := true
+	return r.expect(ctx, key, &Expectation{Ready: &ready, Within: &metav1.Duration{Duration: timeout}})
+}
+
+// scrap deletes an airplane that the runner built, and waits for its
+// teardown to let it go.
+func (r *Runner) scrap(ctx context.Context, key types.NamespacedName) error {
+	timeout := r.ScrapTimeout
+	if timeout <= 0 {
+		timeout = DefaultScrapTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	airplane := &playv1alpha1.Airplane{}
+	airplane.SetName(key.Name)
+	airplane.SetNamespace(key.Namespace)
+	if err := r.Client.Delete(ctx, airplane); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+
+	ticker := time.NewTicker(r.poll())
+	defer ticker.Stop()
+	for {
+		err := r.Client.Get(ctx, key, airplane)
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		if err == nil {
+			err = fmt.Errorf("airplane %s is still being torn down", key.Name)
+		}
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return fmt.Errorf("after %s: %w", timeout, err)
+		}
+	}
+}
+
+func (r *Runner) runStep(ctx context.Context, key types.NamespacedName, step *Step) error {
+	switch {
+	case step.Set != nil:
+		return r.set(ctx, key, step.Set)
+	case step.Expect != nil:
+		return r.expect(ctx, key, step.Expect)
+	case step.Wait != nil:
+		select {
+		case <-time.After(step.Wait.Duration):
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return fmt.Errorf("the step does nothing")
+}
+
+// set moves the airplane's controls, trying again if someone else changed
+// them at the same time, until the context is done.
+func (r *Runner) set(ctx context.Context, key types.NamespacedName, set *Set) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		airplane, pedals, _, err := r.read(ctx, key)
+		if err != nil {
+			return err
+		}
+		if pedals == nil {
+			return fmt.Errorf("airplane %s has no pedals", airplane.GetName())
+		}
+		if len(set.Pedals) > 0 {
+			pedals.Spec.Pressed = set.Pedals
+			pedals.Spec.Travel = nil
+		}
+		if set.PedalTravel != nil {
+			travel := *set.PedalTravel
+			pedals.Spec.Travel = &travel
+		}
+		err = r.Client.Update(ctx, pedals)
+		if !apierrors.IsConflict(err) {
+			return err
+		}
+	}
+}
+
+// expect waits for the airplane to pass every check the expectation gives.
+// The error describes the last check that failed.
+func (r *Runner) expect(ctx context.Context, key types.NamespacedName, expect *Expectation) error {
+	ctx, cancel := context.WithTimeout(ctx, expect.within())
+	defer cancel()
+
+	ticker := time.NewTicker(r.poll())
+	defer ticker.Stop()
+	for {
+		err := r.check(ctx, key, expect)
+		if err == nil {
+			return nil
+		}
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return fmt.Errorf("after %s: %w", expect.within(), err)
+		}
+	}
+}
+
+func (r *Runner) check(ctx context.Context, key types.NamespacedName, expect *Expectation) error {
+	airplane, _, rudder, err := r.read(ctx, key)
+	if err != nil {
+		return err
+	}
+
+	problems := []string{}
+	if expect.Ready != nil {
+		ready := meta.IsStatusConditionTrue(airplane.Status.Conditions, playv1alpha1.ConditionReady)
+		if ready != *expect.Ready {
+			problems = append(problems, fmt.Sprintf("airplane ready is %t", ready))
+		}
+	}
+	if len(expect.Rudder) > 0 || expect.RudderDeflection != nil {
+		if rudder == nil {
+			return fmt.Errorf("airplane %s has no rudder", airplane.GetName())
+		}
+		if len(expect.Rudder) > 0 && rudder.Status.Position != expect.Rudder {
+			problems = append(problems, fmt.Sprintf("rudder is %s", describePosition(rudder.Status.Position)))
+		}
+		if expect.RudderDeflection != nil && rudder.Status.Deflection != *expect.RudderDeflection {
+			problems = append(problems, fmt.Sprintf("rudder is at %d degrees", rudder.Status.Deflection))
+		}
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("%s", strings.Join(problems, ", "))
+	}
+	return nil
+}
+
+func describePosition(position string) string {
+	if len(position) == 0 {
+		return "not reporting a position"
+	}
+	return position
+}
+
+// read gets the airplane, and the pedals and rudder that it has so far.
+func (r *Runner) read(ctx context.Context, key types.NamespacedName) (*playv1alpha1.Airplane, *playv1alpha1.Pedals, *playv1alpha1.Rudder, error) {
+	airplane := &playv1alpha1.Airplane{}
+	if err := r.Client.Get(ctx, key, airplane); err != nil {
+		return nil, nil, nil, err
+	}
+
+	var pedals *playv1alpha1.Pedals
+	if name := airplane.Status.Pedals.Name; len(name) > 0 {
+		pedals = &playv1alpha1.Pedals{}
+		if err := r.Client.Get(ctx, types.NamespacedName{Name: name, Namespace: key.Namespace}, pedals); err != nil {
+			return nil, nil, nil, err
+		}
+	}
+
+	var rudder *playv1alpha1.Rudder
+	if name := airplane.Status.Rudder.Name; len(name) > 0 {
+		rudder = &playv1alpha1.Rudder{}
+		if err := r.Client.Get(ctx, types.NamespacedName{Name: name, Namespace: key.Namespace}, rudder); err != nil {
+			return nil, nil, nil, err
+		}
+	}
+
+	return airplane, pedals, rudder, nil
+}
@@ -0,0 +1,251 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package scenario runs scripted checks of an airplane's controls against a
+// cluster.  A scenario is written in YAML as a list of steps, each of which
+// sets a control, waits, or expects something of the airplane within a
+// time limit:
+//
+//	name: rudder follows the pedals
+//	airplane:
+//	  name: scenario-cessna
+//	  tailNumber: N238CS
+//	steps:
+//	- set:
+//	    pedals: left
+//	- expect:
+//	    rudder: left
+//	    within: 2s
+//	- wait: 1s
+//	- set:
+//	    pedals: none
+//	- expect:
+//	    rudderDeflection: 0
+//
+// The steps run in order, and a scenario stops at the first step that
+// fails.  The results can be written out as JUnit XML.
+package scenario
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+)
+
+// DefaultWithin is how long an expectation waits when the scenario doesn't
+// say.
+const DefaultWithin = 5 * time.Second
+
+// Scenario is a scripted check of an airplane.
+type Scenario struct {
+	// Name describes the scenario.
+	Name string `json:"name"`
+
+	// Airplane is the airplane that the steps are run on.
+	Airplane Airplane `json:"airplane"`
+
+	// Steps are run in order.
+	Steps []Step `json:"steps"`
+}
+
+// Airplane names the airplane that a scenario runs on.  When a tail number
+// is given, the runner builds the airplane for the scenario and deletes it
+// afterwards.  Otherwise the airplane must already exist.
+type Airplane struct {
+	// Name is the name of the Airplane resource.
+	Name string `json:"name"`
+
+	// TailNumber is the tail number of an airplane to build.
+	TailNumber string `json:"tailNumber,omitempty"`
+
+	// Country is the country the airplane to build is registered in.
+	Country string `json:"country,omitempty"`
+
+	// Type names the AircraftType of the airplane to build.
+	Type string `json:"type,omitempty"`
+}
+
+// Step is one step of a scenario.  Exactly one of Set, Expect and Wait is
+// given.
+type Step struct {
+	// Name describes the step.  Steps without a name are described by
+	// what they do.
+	Name string `json:"name,omitempty"`
+
+	// Set moves the airplane's controls.
+	Set *Set `json:"set,omitempty"`
+
+	// Expect checks the airplane's state.
+	Expect *Expectation `json:"expect,omitempty"`
+
+	// Wait pauses the scenario.
+	Wait *metav1.Duration `json:"wait,omitempty"`
+}
+
+// Set moves the airplane's controls.
+type Set struct {
+	// Pedals is which rudder pedal to press: none, left or right.
+	Pedals string `json:"pedals,omitempty"`
+
+	// PedalTravel is how far to push the pedals, as a percentage of full
+	// travel.  Negative is left.
+	PedalTravel *int32 `json:"pedalTravel,omitempty"`
+}
+
+// Expectation checks the airplane's state.  Every check that is given must pass
+// within the time limit.
+type Expectation struct {
+	// Rudder is the rudder's position: left, right or neutral.
+	Rudder string `json:"rudder,omitempty"`
+
+	// RudderDeflection is the rudder's deflection, in degrees.
+	RudderDeflection *int32 `json:"rudderDeflection,omitempty"`
+
+	// Ready is whether the airplane is ready to fly.
+	Ready *bool `json:"ready,omitempty"`
+
+	// Within is how long the checks have to pass.  It defaults to five
+	// seconds.
+	Within *metav1.Duration `json:"within,omitempty"`
+}
+
+// Load reads a scenario from a YAML file.
+func Load(path string) (*Scenario, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	scenario, err := Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return scenario, nil
+}
+
+// Parse reads a scenario from YAML, and checks that it makes sense.
+func Parse(data []byte) (*Scenario, error) {
+	scenario := &Scenario{}
+	if err := yaml.UnmarshalStrict(data, scenario); err != nil {
+		return nil, err
+	}
+	if err := scenario.Validate(); err != nil {
+		return nil, err
+	}
+	return scenario, nil
+}
+
+// Validate checks that the scenario makes sense.
+func (s *Scenario) Validate() error {
+	if len(s.Name) == 0 {
+		return fmt.Errorf("the scenario has no name")
+	}
+	if len(s.Airplane.Name) == 0 {
+		return fmt.Errorf("the scenario does not name an airplane")
+	}
+	if len(s.Steps) == 0 {
+		return fmt.Errorf("the scenario has no steps")
+	}
+	for i := range s.Steps {
+		if err := s.Steps[i].validate(); err != nil {
+			return fmt.Errorf("step %d: %w", i+1, err)
+		}
+	}
+	return nil
+}
+
+func (s *Step) validate() error {
+	given := 0
+	if s.Set != nil {
+		given++
+		if len(s.Set.Pedals) == 0 && s.Set.PedalTravel == nil {
+			return fmt.Errorf("set has nothing to set")
+		}
+		switch s.Set.Pedals {
+		case "", "none", "left", "right":
+		default:
+			return fmt.Errorf("pedals must be none, left or right, not %q", s.Set.Pedals)
+		}
+		if travel := s.Set.PedalTravel; travel != nil && (*travel < -100 || *travel > 100) {
+			return fmt.Errorf("pedalTravel must be between -100 and 100, not %d", *travel)
+		}
+	}
+	if s.Expect != nil {
+		given++
+		if len(s.Expect.Rudder) == 0 && s.Expect.RudderDeflection == nil && s.Expect.Ready == nil {
+			return fmt.Errorf("expect has nothing to check")
+		}
+		switch s.Expect.Rudder {
+		case "", "neutral", "left", "right":
+		default:
+			return fmt.Errorf("rudder must be neutral, left or right, not %q", s.Expect.Rudder)
+		}
+	}
+	if s.Wait != nil {
+		given++
+	}
+	if given != 1 {
+		return fmt.Errorf("a step must have exactly one of set, expect and wait")
+	}
+	return nil
+}
+
+// Describe says what the step does, if the step has no name of its own.
+func (s *Step) Describe() string {
+	if len(s.Name) > 0 {
+		return s.Name
+	}
+
+	parts := []string{}
+	switch {
+	case s.Set != nil:
+		if len(s.Set.Pedals) > 0 {
+			parts = append(parts, "pedals "+s.Set.Pedals)
+		}
+		if s.Set.PedalTravel != nil {
+			parts = append(parts, fmt.Sprintf("pedal travel %d%%", *s.Set.PedalTravel))
+		}
+		return "set " + strings.Join(parts, ", ")
+	case s.Expect != nil:
+		if len(s.Expect.Rudder) > 0 {
+			parts = append(parts, "rudder "+s.Expect.Rudder)
+		}
+		if s.Expect.RudderDeflection != nil {
+			parts = append(parts, fmt.Sprintf("rudder at %d degrees", *s.Expect.RudderDeflection))
+		}
+		if s.Expect.Ready != nil {
+			if *s.Expect.Ready {
+				parts = append(parts, "ready")
+			} else {
+				parts = append(parts, "not ready")
+			}
+		}
+		return fmt.Sprintf("expect %s within %s", strings.Join(parts, ", "), s.Expect.within())
+	case s.Wait != nil:
+		return "wait " + s.Wait.Duration.String()
+	}
+	return "nothing"
+}
+
+func (e *Expectation) within() time.Duration {
+	if e.Within == nil {
+		return DefaultWithin
+	}
+	return e.Within.Duration
+}
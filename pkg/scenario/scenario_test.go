@@ -0,0 +1,224 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scenario
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	playv1alpha1 "github.com/roehrich-hpe/airplane-sim/api/v1alpha1"
+)
+
+var _ = Describe("Scenarios", func() {
+
+	It("Reads a scenario", func() {
+		scenario, err := Parse([]byte(`
+name: pedals
+airplane:
+  name: cessna
+steps:
+- set:
+    pedals: left
+- expect:
+    rudder: left
+    within: 2s
+- wait: 1s
+- name: center the pedals
+  set:
+    pedalTravel: 0
+`))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(scenario.Steps).To(HaveLen(4))
+		Expect(scenario.Steps[0].Describe()).To(Equal("set pedals left"))
+		Expect(scenario.Steps[1].Describe()).To(Equal("expect rudder left within 2s"))
+		Expect(scenario.Steps[2].Describe()).To(Equal("wait 1s"))
+		Expect(scenario.Steps[3].Describe()).To(Equal("center the pedals"))
+	})
+
+	It("Reads the example scenarios", func() {
+		_, err := Load("../../config/scenarios/rudder-follows-pedals.yaml")
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	DescribeTable("rejects scenarios that don't make sense",
+		func(yaml string) {
+			_, err := Parse([]byte(yaml))
+			Expect(err).To(HaveOccurred())
+		},
+		Entry("no name", "airplane: {name: a}\nsteps: [{wait: 1s}]"),
+		Entry("no airplane", "name: s\nsteps: [{wait: 1s}]"),
+		Entry("no steps", "name: s\nairplane: {name: a}"),
+		Entry("an empty step", "name: s\nairplane: {name: a}\nsteps: [{name: nothing}]"),
+		Entry("two things in a step", "name: s\nairplane: {name: a}\nsteps: [{wait: 1s, set: {pedals: left}}]"),
+		Entry("a bad pedal", "name: s\nairplane: {name: a}\nsteps: [{set: {pedals: up}}]"),
+		Entry("too much travel", "name: s\nairplane: {name: a}\nsteps: [{set: {pedalTravel: 120}}]"),
+		Entry("nothing to expect", "name: s\nairplane: {name: a}\nsteps: [{expect: {within: 1s}}]"),
+		Entry("a bad rudder", "name: s\nairplane: {name: a}\nsteps: [{expect: {rudder: up}}]"),
+		Entry("an unknown field", "name: s\nairplane: {name: a}\nsteps: [{wait: 1s, jump: true}]"),
+	)
+})
+
+var _ = Describe("Runner", func() {
+
+	var (
+		runner *Runner
+		pedals *playv1alpha1.Pedals
+	)
+
+	BeforeEach(func() {
+		scheme := runtime.NewScheme()
+		Expect(playv1alpha1.AddToScheme(scheme)).To(Succeed())
+
+		airplane := &playv1alpha1.Airplane{
+			ObjectMeta: metav1.ObjectMeta{Name: "cessna", Namespace: corev1.NamespaceDefault},
+			Status: playv1alpha1.AirplaneStatus{
+				Pedals: corev1.ObjectReference{Kind: "Pedals", Name: "n238cs"},
+				Rudder: corev1.ObjectReference{Kind: "Rudder", Name: "n238cs"},
+			},
+		}
+		pedals = &playv1alpha1.Pedals{
+			ObjectMeta: metav1.ObjectMeta{Name: "n238cs", Namespace: corev1.NamespaceDefault},
+		}
+		rudder := &playv1alpha1.Rudder{
+			ObjectMeta: metav1.ObjectMeta{Name: "n238cs", Namespace: corev1.NamespaceDefault},
+			Status:     playv1alpha1.RudderStatus{Position: "neutral"},
+		}
+
+		// Nothing moves the rudder in a fake cluster.
+		runner = &Runner{
+			Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(airplane, pedals, rudder).Build(),
+			Poll:   10 * time.Millisecond,
+		}
+	})
+
+	It("Runs the steps until one fails, and reports them as JUnit", func() {
+		scenario, err := Parse([]byte(`
+name: stuck rudder
+airplane:
+  name: cessna
+steps:
+- set:
+    pedals: left
+- expect:
+    rudder: neutral
+    within: 100ms
+- expect:
+    rudder: left
+    within: 100ms
+- wait: 1h
+`))
+		Expect(err).NotTo(HaveOccurred())
+
+		result := runner.Run(context.TODO(), scenario)
+		Expect(result.Failed()).To(BeTrue())
+		Expect(result.Steps).To(HaveLen(4))
+		Expect(result.Steps[0].Err).NotTo(HaveOccurred())
+		Expect(result.Steps[1].Err).NotTo(HaveOccurred())
+		Expect(result.Steps[2].Err).To(MatchError(ContainSubstring("rudder is neutral")))
+		Expect(result.Steps[3].Skipped).To(BeTrue())
+
+		Expect(runner.Client.Get(context.TODO(), types.NamespacedName{Name: "n238cs", Namespace: corev1.NamespaceDefault}, pedals)).To(Succeed())
+		Expect(pedals.Spec.Pressed).To(Equal("left"))
+
+		out := &bytes.Buffer{}
+		Expect(WriteJUnit(out, []*Result{result})).To(Succeed())
+		report := junitTestSuites{}
+		Expect(xml.Unmarshal(out.Bytes(), &report)).To(Succeed())
+		Expect(report.Tests).To(Equal(4))
+		Expect(report.Failures).To(Equal(1))
+		Expect(report.Skipped).To(Equal(1))
+		Expect(report.Suites).To(HaveLen(1))
+		Expect(report.Suites[0].Name).To(Equal("stuck rudder"))
+		Expect(report.Suites[0].TestCases[2].Name).To(Equal("3: expect rudder left within 100ms"))
+		Expect(report.Suites[0].TestCases[2].Failure).NotTo(BeNil())
+		Expect(report.Suites[0].TestCases[3].Skipped).NotTo(BeNil())
+	})
+
+	It("Fails when the airplane is missing", func() {
+		scenario, err := Parse([]byte("name: s\nairplane: {name: piper}\nsteps: [{set: {pedals: left}}]"))
+		Expect(err).NotTo(HaveOccurred())
+		result := runner.Run(context.TODO(), scenario)
+		Expect(result.Failed()).To(BeTrue())
+	})
+
+	It("Stops moving the controls once the context is done", func() {
+		scenario, err := Parse([]byte("name: s\nairplane: {name: cessna}\nsteps: [{set: {pedals: left}}]"))
+		Expect(err).NotTo(HaveOccurred())
+
+		ctx, cancel := context.WithCancel(context.TODO())
+		cancel()
+		result := runner.Run(ctx, scenario)
+		Expect(result.Steps).To(HaveLen(1))
+		Expect(result.Steps[0].Err).To(MatchError(context.Canceled))
+	})
+
+	It("Scraps the airplane it built, even after a step fails", func() {
+		// Nothing assembles the airplane in a fake cluster.
+		runner.AssemblyTimeout = 100 * time.Millisecond
+		scenario, err := Parse([]byte("name: s\nairplane: {name: piper, tailNumber: N4521}\nsteps: [{wait: 1h}]"))
+		Expect(err).NotTo(HaveOccurred())
+
+		result := runner.Run(context.TODO(), scenario)
+		Expect(result.Steps).To(HaveLen(3))
+		Expect(result.Steps[0].Err).To(MatchError(ContainSubstring("airplane ready is false")))
+		Expect(result.Steps[1].Skipped).To(BeTrue())
+		Expect(result.Steps[2].Name).To(Equal("scrap airplane N4521"))
+		Expect(result.Steps[2].Err).NotTo(HaveOccurred())
+		Expect(runner.Client.Get(context.TODO(), types.NamespacedName{Name: "piper", Namespace: corev1.NamespaceDefault}, &playv1alpha1.Airplane{})).To(Satisfy(apierrors.IsNotFound))
+	})
+
+	It("Reports an airplane that won't be torn down", func() {
+		runner.AssemblyTimeout = 100 * time.Millisecond
+		runner.ScrapTimeout = 100 * time.Millisecond
+		scenario, err := Parse([]byte("name: s\nairplane: {name: piper, tailNumber: N4521}\nsteps: [{wait: 1ms}]"))
+		Expect(err).NotTo(HaveOccurred())
+
+		// A finalizer that nothing removes keeps the airplane around.
+		runner.Client = &createInterceptor{Client: runner.Client, mutate: func(obj client.Object) {
+			obj.SetFinalizers([]string{"play.github.com/teardown"})
+		}}
+
+		result := runner.Run(context.TODO(), scenario)
+		Expect(result.Failed()).To(BeTrue())
+		Expect(result.Steps).To(HaveLen(3))
+		Expect(result.Steps[2].Err).To(MatchError(ContainSubstring("airplane piper is still being torn down")))
+	})
+})
+
+// createInterceptor changes objects on their way into the cluster.
+type createInterceptor struct {
+	client.Client
+	mutate func(client.Object)
+}
+
+func (c *createInterceptor) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	c.mutate(obj)
+	return c.Client.Create(ctx, obj, opts...)
+}
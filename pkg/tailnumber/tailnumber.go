@@ -0,0 +1,134 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package tailnumber checks aircraft registration marks against the rules of
+// the register that issued them.  Each register is picked by the ISO 3166
+// code of its country.
+//
+// United States N-numbers follow the FAA's rules: "N" and then one to five
+// characters, the first of them a digit other than zero, with at most two
+// letters at the end.  The letters I and O are never used, so they can't be
+// mistaken for 1 and 0.  The other registers are checked against the usual
+// format of their marks.
+package tailnumber
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// DefaultCountry is the register used when an airplane doesn't name one.
+const DefaultCountry = "US"
+
+// Registry describes a national register of aircraft.
+type Registry struct {
+	// Country is the ISO 3166 code of the register's country.
+	Country string
+
+	// Prefix is the nationality mark that every registration in the
+	// register starts with.
+	Prefix string
+
+	// Format describes the marks, for people to read.
+	Format string
+
+	pattern *regexp.Regexp
+}
+
+var registries = map[string]Registry{
+	"US": {Country: "US", Prefix: "N", Format: "N12345, N1234A or N123AB"},
+	"GB": {Country: "GB", Prefix: "G-", Format: "G-ABCD", pattern: regexp.MustCompile(`^G-[A-Z]{4}$`)},
+	"DE": {Country: "DE", Prefix: "D-", Format: "D-ABCD", pattern: regexp.MustCompile(`^D-[A-Z]{4}$`)},
+	"CA": {Country: "CA", Prefix: "C-", Format: "C-FABC, C-GABC or C-IABC", pattern: regexp.MustCompile(`^C-[FGI][A-Z]{3}$`)},
+	"AU": {Country: "AU", Prefix: "VH-", Format: "VH-ABC", pattern: regexp.MustCompile(`^VH-[A-Z]{3}$`)},
+}
+
+// Lookup returns the register of the given country.
+func Lookup(country string) (Registry, bool) {
+	registry, ok := registries[country]
+	return registry, ok
+}
+
+// Countries returns the codes of the countries whose registers are known,
+// in order.
+func Countries() []string {
+	countries := make([]string, 0, len(registries))
+	for country := range registries {
+		countries = append(countries, country)
+	}
+	sort.Strings(countries)
+	return countries
+}
+
+// Validate checks that the tail number is a valid mark in the register of
+// the given country.  An empty country means the United States.
+func Validate(country, tailNumber string) error {
+	if len(country) == 0 {
+		country = DefaultCountry
+	}
+	registry, ok := Lookup(country)
+	if !ok {
+		return fmt.Errorf("no register is known for country %q; use one of %s", country, strings.Join(Countries(), ", "))
+	}
+	return registry.Validate(tailNumber)
+}
+
+// Validate checks that the tail number is a valid mark in this register.
+func (r Registry) Validate(tailNumber string) error {
+	if !strings.HasPrefix(tailNumber, r.Prefix) {
+		return fmt.Errorf("must start with %q in the %s register", r.Prefix, r.Country)
+	}
+	if r.pattern == nil {
+		return validateNNumber(tailNumber)
+	}
+	if !r.pattern.MatchString(tailNumber) {
+		return fmt.Errorf("must look like %s", r.Format)
+	}
+	return nil
+}
+
+// validateNNumber applies the FAA's rules to an N-number.
+func validateNNumber(tailNumber string) error {
+	mark := strings.TrimPrefix(tailNumber, "N")
+	if len(mark) < 1 || len(mark) > 5 {
+		return fmt.Errorf("must have one to five characters after the N")
+	}
+	if mark[0] < '1' || mark[0] > '9' {
+		return fmt.Errorf("must have a digit other than zero after the N")
+	}
+
+	letters := 0
+	for _, c := range mark {
+		switch {
+		case c >= '0' && c <= '9':
+			if letters > 0 {
+				return fmt.Errorf("may only have letters at the end")
+			}
+		case c == 'I' || c == 'O':
+			return fmt.Errorf("must not use the letters I or O")
+		case c >= 'A' && c <= 'Z':
+			letters++
+		default:
+			return fmt.Errorf("must have only digits and capital letters")
+		}
+	}
+	if letters > 2 {
+		return fmt.Errorf("may have at most two letters")
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tailnumber
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Tail Numbers", func() {
+
+	DescribeTable("checks N-numbers against the FAA's rules",
+		func(tailNumber string, isValid bool) {
+			if isValid {
+				Expect(Validate("US", tailNumber)).To(Succeed())
+			} else {
+				Expect(Validate("US", tailNumber)).ToNot(Succeed())
+			}
+		},
+		Entry("one digit", "N1", true),
+		Entry("five digits", "N12345", true),
+		Entry("one letter", "N1234Z", true),
+		Entry("two letters", "N12AB", true),
+		Entry("only a digit and two letters", "N1AB", true),
+		Entry("nothing after the N", "N", false),
+		Entry("no N", "G-ABCD", false),
+		Entry("a leading zero", "N0ABCD", false),
+		Entry("a leading letter", "NABC", false),
+		Entry("too long", "N123456", false),
+		Entry("three letters", "N12ABC", false),
+		Entry("a letter in the middle", "N1A23", false),
+		Entry("the letter I", "N12I", false),
+		Entry("the letter O", "N12O", false),
+		Entry("lower case", "N12ab", false),
+	)
+
+	DescribeTable("checks marks from other registers",
+		func(country, tailNumber string, isValid bool) {
+			if isValid {
+				Expect(Validate(country, tailNumber)).To(Succeed())
+			} else {
+				Expect(Validate(country, tailNumber)).ToNot(Succeed())
+			}
+		},
+		Entry("United Kingdom", "GB", "G-ABCD", true),
+		Entry("United Kingdom, too short", "GB", "G-ABC", false),
+		Entry("Germany", "DE", "D-EFGH", true),
+		Entry("Germany, without the hyphen", "DE", "DEFGH", false),
+		Entry("Canada", "CA", "C-FABC", true),
+		Entry("Canada, wrong series", "CA", "C-AABC", false),
+		Entry("Australia", "AU", "VH-ABC", true),
+		Entry("Australia, digits", "AU", "VH-123", false),
+		Entry("another country's mark", "GB", "N12345", false),
+		Entry("an unknown country", "ZZ", "N12345", false),
+	)
+
+	It("Defaults to the United States", func() {
+		Expect(Validate("", "N12AB")).To(Succeed())
+		Expect(Validate("", "G-ABCD")).ToNot(Succeed())
+	})
+
+	It("Lists the countries it knows", func() {
+		Expect(Countries()).To(Equal([]string{"AU", "CA", "DE", "GB", "US"}))
+	})
+})